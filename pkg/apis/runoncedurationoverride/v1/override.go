@@ -5,7 +5,10 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -23,7 +26,20 @@ func (in *RunOnceDurationOverride) IsTimeToRotateCert() bool {
 }
 
 func (in *RunOnceDurationOverrideConfigSpec) String() string {
-	return fmt.Sprintf("ActiveDeadlineSeconds=%d", in.ActiveDeadlineSeconds)
+	value := fmt.Sprintf("ActiveDeadlineSeconds=%d", in.ActiveDeadlineSeconds)
+	if in.Mode != "" {
+		value = fmt.Sprintf("%s Mode=%s", value, in.Mode)
+	}
+	if len(in.NamespaceOverrides) == 0 {
+		return value
+	}
+
+	rules := make([]string, 0, len(in.NamespaceOverrides))
+	for i := range in.NamespaceOverrides {
+		rules = append(rules, in.NamespaceOverrides[i].String())
+	}
+
+	return fmt.Sprintf("%s NamespaceOverrides=[%s]", value, strings.Join(rules, ","))
 }
 
 func (in *RunOnceDurationOverrideConfigSpec) Validate() error {
@@ -31,9 +47,30 @@ func (in *RunOnceDurationOverrideConfigSpec) Validate() error {
 		return errors.New("invalid value for ActiveDeadlineSeconds, must be a positive value")
 	}
 
+	switch in.Mode {
+	case "", OverrideModeEnforce, OverrideModeAudit, OverrideModeDisabled:
+	default:
+		return fmt.Errorf("invalid value for Mode %q, must be one of Enforce, Audit or Disabled", in.Mode)
+	}
+
+	for i := range in.NamespaceOverrides {
+		if err := in.NamespaceOverrides[i].Validate(); err != nil {
+			return fmt.Errorf("invalid NamespaceOverrides[%d] - %s", i, err.Error())
+		}
+	}
+
 	return nil
 }
 
+// GetMode returns the enforcement mode, defaulting to Enforce.
+func (in *RunOnceDurationOverrideConfigSpec) GetMode() OverrideMode {
+	if in.Mode == "" {
+		return OverrideModeEnforce
+	}
+
+	return in.Mode
+}
+
 func (in *RunOnceDurationOverrideConfigSpec) Hash() string {
 	value := fmt.Sprintf("%s", in)
 
@@ -44,3 +81,191 @@ func (in *RunOnceDurationOverrideConfigSpec) Hash() string {
 	}
 	return hex.EncodeToString(writer.Sum(nil))
 }
+
+func (in *NamespaceActiveDeadlineOverride) String() string {
+	return fmt.Sprintf("{NamespaceSelector=%s ActiveDeadlineSeconds=%d}", metav1.FormatLabelSelector(&in.NamespaceSelector), in.ActiveDeadlineSeconds)
+}
+
+func (in *NamespaceActiveDeadlineOverride) Validate() error {
+	if in.ActiveDeadlineSeconds < 0 {
+		return errors.New("invalid value for ActiveDeadlineSeconds, must be a positive value")
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(&in.NamespaceSelector); err != nil {
+		return fmt.Errorf("invalid value for NamespaceSelector - %s", err.Error())
+	}
+
+	return nil
+}
+
+func (in *WebhookConfig) Validate() error {
+	switch in.FailurePolicy {
+	case "", admissionregistrationv1.Fail, admissionregistrationv1.Ignore:
+	default:
+		return fmt.Errorf("invalid value for FailurePolicy %q, must be one of Fail or Ignore", in.FailurePolicy)
+	}
+
+	if in.TimeoutSeconds != 0 && (in.TimeoutSeconds < 1 || in.TimeoutSeconds > 30) {
+		return errors.New("invalid value for TimeoutSeconds, must be between 1 and 30")
+	}
+
+	if in.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(in.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid value for NamespaceSelector - %s", err.Error())
+		}
+	}
+
+	if in.ObjectSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(in.ObjectSelector); err != nil {
+			return fmt.Errorf("invalid value for ObjectSelector - %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// GetDeploymentMode returns the requested deployment mode, defaulting to Webhook.
+func (in *RunOnceDurationOverrideSpec) GetDeploymentMode() DeploymentMode {
+	if in.DeploymentMode == "" {
+		return DeploymentModeWebhook
+	}
+
+	return in.DeploymentMode
+}
+
+// GetWorkload returns the requested workload, defaulting to DaemonSet.
+func (in *RunOnceDurationOverrideSpec) GetWorkload() WorkloadType {
+	if in.Workload == "" {
+		return WorkloadDaemonSet
+	}
+
+	return in.Workload
+}
+
+// GetReplicas returns the number of replicas of the Deployment workload, defaulting to 2.
+func (in *RunOnceDurationOverrideSpec) GetReplicas() int32 {
+	if in.Replicas == 0 {
+		return 2
+	}
+
+	return in.Replicas
+}
+
+// GetCertSource returns the requested serving cert issuer, defaulting to SelfSigned.
+func (in *RunOnceDurationOverrideSpec) GetCertSource() CertSource {
+	if in.CertSource == "" {
+		return CertSourceSelfSigned
+	}
+
+	return in.CertSource
+}
+
+const (
+	// DefaultCertValidity is the default duration the self-signed certs are valid for.
+	DefaultCertValidity = time.Hour * 24 * 365
+
+	// DefaultCertRotationThreshold is the default threshold preceding the expiration
+	// date of the self-signed certs at which they are rotated.
+	DefaultCertRotationThreshold = time.Hour * 48
+
+	// MinCertValidity is the shortest validity accepted for the self-signed certs.
+	MinCertValidity = time.Hour * 24
+)
+
+// GetValidity returns the validity of the self-signed certs, defaulting to 365 days.
+func (in *CertificateConfig) GetValidity() time.Duration {
+	if in.Validity == nil {
+		return DefaultCertValidity
+	}
+
+	return in.Validity.Duration
+}
+
+// GetRotationThreshold returns how long before expiry the self-signed certs are
+// rotated, defaulting to 48h.
+func (in *CertificateConfig) GetRotationThreshold() time.Duration {
+	if in.RotationThreshold == nil {
+		return DefaultCertRotationThreshold
+	}
+
+	return in.RotationThreshold.Duration
+}
+
+// GetKeyAlgorithm returns the algorithm of the private keys, defaulting to ECDSA-P256.
+func (in *CertificateConfig) GetKeyAlgorithm() KeyAlgorithm {
+	if in.KeyAlgorithm == "" {
+		return KeyAlgorithmECDSAP256
+	}
+
+	return in.KeyAlgorithm
+}
+
+func (in *CertificateConfig) Validate() error {
+	if in.GetValidity() < MinCertValidity {
+		return fmt.Errorf("invalid value for Validity, must be at least %s", MinCertValidity)
+	}
+
+	if in.GetRotationThreshold() <= 0 {
+		return errors.New("invalid value for RotationThreshold, must be a positive value")
+	}
+
+	if in.GetRotationThreshold() >= in.GetValidity() {
+		return errors.New("invalid value for RotationThreshold, must be shorter than Validity")
+	}
+
+	switch in.KeyAlgorithm {
+	case "", KeyAlgorithmECDSAP256, KeyAlgorithmECDSAP384, KeyAlgorithmRSA3072:
+	default:
+		return fmt.Errorf("invalid value for KeyAlgorithm %q, must be one of ECDSA-P256, ECDSA-P384 or RSA-3072", in.KeyAlgorithm)
+	}
+
+	return nil
+}
+
+func (in *RunOnceDurationOverrideSpec) Validate() error {
+	if err := in.RunOnceDurationOverrideConfig.Spec.Validate(); err != nil {
+		return err
+	}
+
+	if err := in.Webhook.Validate(); err != nil {
+		return fmt.Errorf("invalid Webhook - %s", err.Error())
+	}
+
+	switch in.DeploymentMode {
+	case "", DeploymentModeWebhook, DeploymentModeAdmissionPolicy:
+	default:
+		return fmt.Errorf("invalid value for DeploymentMode %q, must be one of Webhook or AdmissionPolicy", in.DeploymentMode)
+	}
+
+	switch in.Workload {
+	case "", WorkloadDaemonSet, WorkloadDeployment:
+	default:
+		return fmt.Errorf("invalid value for Workload %q, must be one of DaemonSet or Deployment", in.Workload)
+	}
+
+	if in.Replicas < 0 {
+		return errors.New("invalid value for Replicas, must be a positive value")
+	}
+
+	switch in.CertSource {
+	case "", CertSourceSelfSigned:
+	case CertSourceServiceCA:
+		// The service-ca operator issues certs for the Service, which only the
+		// Deployment workload is reached through.
+		if in.GetWorkload() != WorkloadDeployment {
+			return errors.New("invalid value for CertSource, ServiceCA requires the Deployment workload")
+		}
+	case CertSourceUserProvided:
+		if in.UserProvidedCert == nil || in.UserProvidedCert.SecretName == "" || in.UserProvidedCert.CAConfigMapName == "" {
+			return errors.New("invalid value for CertSource, UserProvided requires UserProvidedCert with SecretName and CAConfigMapName")
+		}
+	default:
+		return fmt.Errorf("invalid value for CertSource %q, must be one of SelfSigned, ServiceCA or UserProvided", in.CertSource)
+	}
+
+	if err := in.Certificate.Validate(); err != nil {
+		return fmt.Errorf("invalid Certificate - %s", err.Error())
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -15,12 +16,23 @@ const (
 	InstallReadinessFailure      = "InstallReadinessFailure"
 	InvalidParameters            = "InvalidParameters"
 	ConfigurationCheckFailed     = "ConfigurationCheckFailed"
+	ConfigurationNotAvailable    = "ConfigurationNotAvailable"
 	CertNotAvailable             = "CertNotAvailable"
 	CannotSetReference           = "CannotSetReference"
 	CannotGenerateCert           = "CannotGenerateCert"
 	InternalError                = "InternalError"
 	AdmissionWebhookNotAvailable = "AdmissionWebhookNotAvailable"
 	DeploymentNotReady           = "DeploymentNotReady"
+	CannotRemoveOperand          = "CannotRemoveOperand"
+	OperandRemoved               = "OperandRemoved"
+	WebhookConfigurationDrift    = "WebhookConfigurationDrift"
+	DriftRepaired                = "DriftRepaired"
+	AsExpected                   = "AsExpected"
+	DeploymentModeFallback       = "DeploymentModeFallback"
+	AdmissionPolicyNotServed     = "AdmissionPolicyNotServed"
+	UserProvidedCertInvalid      = "UserProvidedCertInvalid"
+	RollingOut                   = "RollingOut"
+	ConfigurationChanged         = "ConfigurationChanged"
 )
 
 // +genclient
@@ -46,6 +58,186 @@ type RunOnceDurationOverrideSpec struct {
 	operatorsv1.OperatorSpec `json:",inline"`
 
 	RunOnceDurationOverrideConfig RunOnceDurationOverrideConfig `json:"runOnceDurationOverride"`
+
+	// Webhook configures how the admission webhook is registered with the API server.
+	// +optional
+	Webhook WebhookConfig `json:"webhook,omitempty"`
+
+	// DeploymentMode selects how the override is implemented.
+	// Webhook runs the admission webhook server.
+	// AdmissionPolicy generates a MutatingAdmissionPolicy and its binding instead,
+	// and falls back to Webhook when the MutatingAdmissionPolicy API is not served.
+	// Defaults to Webhook.
+	// +optional
+	// +kubebuilder:validation:Enum=Webhook;AdmissionPolicy
+	DeploymentMode DeploymentMode `json:"deploymentMode,omitempty"`
+
+	// Workload selects how the admission webhook server runs in the Webhook deployment mode.
+	// DaemonSet runs it with hostNetwork on every master node, reached at https://localhost:9448.
+	// Deployment runs a replicated Deployment behind a Service, reached through a service reference,
+	// for clusters where the control plane is hosted elsewhere.
+	// Defaults to DaemonSet.
+	// +optional
+	// +kubebuilder:validation:Enum=DaemonSet;Deployment
+	Workload WorkloadType `json:"workload,omitempty"`
+
+	// Replicas is the number of webhook server pods of the Deployment workload.
+	// Defaults to 2.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// CertSource selects where the serving cert of the webhook server comes from.
+	// SelfSigned generates a self-signed CA and serving cert, rotated by the operator.
+	// ServiceCA uses the serving cert issued by the OpenShift service-ca operator for the
+	// operand Service and the CA bundle it injects, rotation is then owned by the platform.
+	// ServiceCA requires the Deployment workload.
+	// UserProvided uses the serving cert and CA bundle referenced by UserProvidedCert,
+	// rotation is then owned by whoever maintains them.
+	// Defaults to SelfSigned.
+	// +optional
+	// +kubebuilder:validation:Enum=SelfSigned;ServiceCA;UserProvided
+	CertSource CertSource `json:"certSource,omitempty"`
+
+	// UserProvidedCert references the serving cert and CA bundle of the UserProvided
+	// cert source. Both live in the operand namespace.
+	// +optional
+	UserProvidedCert *UserProvidedCertConfig `json:"userProvidedCert,omitempty"`
+
+	// Certificate configures the self-signed serving certs generated by the operator.
+	// It is ignored by the ServiceCA cert source.
+	// +optional
+	Certificate CertificateConfig `json:"certificate,omitempty"`
+
+	// OperandPlacement configures where the webhook server pods are scheduled and
+	// the resources they get. Changes roll out the workload.
+	// +optional
+	OperandPlacement OperandPlacement `json:"operandPlacement,omitempty"`
+}
+
+// OperandPlacement holds the scheduling settings of the webhook server pods.
+type OperandPlacement struct {
+	// NodeSelector replaces the default node selector of the webhook server pods.
+	// The DaemonSet workload defaults to node-role.kubernetes.io/master, the
+	// Deployment workload to none.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations replaces the default tolerations of the webhook server pods.
+	// The DaemonSet workload defaults to tolerating the node-role.kubernetes.io/master
+	// NoSchedule taint, and unreachable or not-ready nodes for 120 seconds.
+	// +optional
+	// +listType=atomic
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Resources are the compute resources of the webhook server container.
+	// Defaults to no requests and limits.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PriorityClassName is the priority class of the webhook server pods.
+	// Defaults to none.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// UserProvidedCertConfig references a serving cert and CA bundle issued outside of the operator.
+type UserProvidedCertConfig struct {
+	// SecretName is the name of the kubernetes.io/tls Secret holding the serving
+	// cert (tls.crt) and its private key (tls.key).
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+
+	// CAConfigMapName is the name of the ConfigMap holding the PEM encoded CA
+	// that signed the serving cert, under the ca-bundle.crt key.
+	// +kubebuilder:validation:MinLength=1
+	CAConfigMapName string `json:"caConfigMapName"`
+}
+
+// CertificateConfig holds the settings of the self-signed serving certs.
+// Changes are picked up by a staged rotation of the current certs.
+type CertificateConfig struct {
+	// Validity is how long the generated CA and serving cert are valid for.
+	// It must be at least 24h. Defaults to 8760h (365 days).
+	// +optional
+	Validity *metav1.Duration `json:"validity,omitempty"`
+
+	// RotationThreshold is how long before expiry the certs are rotated.
+	// It must be shorter than Validity. Defaults to 48h.
+	// +optional
+	RotationThreshold *metav1.Duration `json:"rotationThreshold,omitempty"`
+
+	// KeyAlgorithm is the algorithm and size of the generated private keys.
+	// Defaults to ECDSA-P256.
+	// +optional
+	// +kubebuilder:validation:Enum=ECDSA-P256;ECDSA-P384;RSA-3072
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+}
+
+// KeyAlgorithm is the algorithm and size of a generated private key.
+type KeyAlgorithm string
+
+const (
+	KeyAlgorithmECDSAP256 KeyAlgorithm = "ECDSA-P256"
+	KeyAlgorithmECDSAP384 KeyAlgorithm = "ECDSA-P384"
+	KeyAlgorithmRSA3072   KeyAlgorithm = "RSA-3072"
+)
+
+// CertSource is the issuer of the webhook serving cert.
+type CertSource string
+
+const (
+	CertSourceSelfSigned   CertSource = "SelfSigned"
+	CertSourceServiceCA    CertSource = "ServiceCA"
+	CertSourceUserProvided CertSource = "UserProvided"
+)
+
+// UserProvidedCABundleKey is the key of the CA bundle in the ConfigMap referenced
+// by UserProvidedCertConfig.
+const UserProvidedCABundleKey = "ca-bundle.crt"
+
+// WorkloadType is the kind of workload that runs the admission webhook server.
+type WorkloadType string
+
+const (
+	WorkloadDaemonSet  WorkloadType = "DaemonSet"
+	WorkloadDeployment WorkloadType = "Deployment"
+)
+
+// DeploymentMode is the way the override is implemented in the cluster.
+type DeploymentMode string
+
+const (
+	DeploymentModeWebhook         DeploymentMode = "Webhook"
+	DeploymentModeAdmissionPolicy DeploymentMode = "AdmissionPolicy"
+)
+
+// WebhookConfig holds the registration settings of the admission webhook.
+type WebhookConfig struct {
+	// FailurePolicy defines how the API server handles errors calling the webhook.
+	// Allowed values are Fail and Ignore. Defaults to Fail.
+	// +optional
+	// +kubebuilder:validation:Enum=Fail;Ignore
+	FailurePolicy admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+
+	// TimeoutSeconds is the time the API server waits for the webhook to respond.
+	// It must be between 1 and 30 seconds. Defaults to 5 seconds.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// NamespaceSelector selects the namespaces whose pods are sent to the webhook.
+	// Namespaces with openshift.io/run-level 0 or 1 are always excluded.
+	// Defaults to namespaces labeled
+	// runoncedurationoverrides.admission.runoncedurationoverride.openshift.io/enabled=true.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ObjectSelector selects the pods that are sent to the webhook by their labels.
+	// Defaults to all pods in the selected namespaces.
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
 }
 
 // RunOnceDurationOverrideConfig is the configuration for the admission controller which
@@ -59,6 +251,47 @@ type RunOnceDurationOverrideConfig struct {
 type RunOnceDurationOverrideConfigSpec struct {
 	// ActiveDeadlineSeconds (if > 0) overrides activeDeadlineSeconds field of pod;
 	// if pod's restartPolicy is set to Never or OnFailure.
+	// It is the default value applied to pods whose namespace does not match
+	// any of the NamespaceOverrides rules.
+	ActiveDeadlineSeconds int64 `json:"activeDeadlineSeconds"`
+
+	// Mode controls how the admission webhook enforces the override.
+	// Enforce sets activeDeadlineSeconds on matching pods.
+	// Audit only annotates matching pods and returns a warning, leaving
+	// activeDeadlineSeconds unchanged.
+	// Disabled removes the webhook registration, the webhook server keeps running.
+	// Defaults to Enforce.
+	// +optional
+	// +kubebuilder:validation:Enum=Enforce;Audit;Disabled
+	Mode OverrideMode `json:"mode,omitempty"`
+
+	// NamespaceOverrides is an ordered list of rules that set a different
+	// activeDeadlineSeconds for pods in the namespaces selected by the rule.
+	// The first rule whose namespaceSelector matches the labels of the pod's
+	// namespace wins; if no rule matches, ActiveDeadlineSeconds is used.
+	// +optional
+	// +listType=atomic
+	NamespaceOverrides []NamespaceActiveDeadlineOverride `json:"namespaceOverrides,omitempty"`
+}
+
+// OverrideMode is the enforcement mode of the admission webhook.
+type OverrideMode string
+
+const (
+	OverrideModeEnforce  OverrideMode = "Enforce"
+	OverrideModeAudit    OverrideMode = "Audit"
+	OverrideModeDisabled OverrideMode = "Disabled"
+)
+
+// NamespaceActiveDeadlineOverride overrides activeDeadlineSeconds for the
+// namespaces selected by NamespaceSelector.
+type NamespaceActiveDeadlineOverride struct {
+	// NamespaceSelector selects the namespaces this rule applies to.
+	// An empty selector matches all namespaces.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+
+	// ActiveDeadlineSeconds (if > 0) overrides activeDeadlineSeconds field of pod
+	// in the selected namespaces.
 	ActiveDeadlineSeconds int64 `json:"activeDeadlineSeconds"`
 }
 
@@ -73,12 +306,109 @@ type RunOnceDurationOverrideStatus struct {
 	// CertsRotateAt is the time the serving certs will be rotated at.
 	// +optional
 	CertsRotateAt metav1.Time `json:"certsRotateAt,omitempty"`
+
+	// DeploymentMode is the deployment mode currently in effect.
+	// +optional
+	DeploymentMode DeploymentMode `json:"deploymentMode,omitempty"`
+
+	// Workload is the workload currently running the admission webhook server.
+	// +optional
+	Workload WorkloadType `json:"workload,omitempty"`
+
+	// CertSource is the issuer of the serving cert currently in use.
+	// +optional
+	CertSource CertSource `json:"certSource,omitempty"`
+
+	// CertRotation tracks the staged rotation of the self-signed serving certs.
+	// +optional
+	CertRotation CertRotationStatus `json:"certRotation,omitempty"`
+
+	// Certificates describes the serving cert in use and the CA that signed it.
+	// +optional
+	Certificates CertificatesStatus `json:"certificates,omitempty"`
 }
 
+// CertRotationStatus tracks the staged rotation of the self-signed serving certs.
+// The new CA is trusted next to the old one before the serving cert it signed is
+// rolled out, and the old CA is dropped once every pod serves the new cert.
+type CertRotationStatus struct {
+	// Phase is the phase of the rotation in progress, empty when there is none.
+	// TrustingNewCA: the CA bundle holds the old and the new CA, pods serve the old cert.
+	// RollingOutServingCert: the CA bundle holds the old and the new CA, the serving
+	// cert signed by the new CA is being rolled out.
+	// +optional
+	Phase CertRotationPhase `json:"phase,omitempty"`
+
+	// LastTransitionTime is the time the rotation entered the current phase.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// LastCompletionTime is the time the last staged rotation completed.
+	// +optional
+	LastCompletionTime metav1.Time `json:"lastCompletionTime,omitempty"`
+}
+
+// CertificatesStatus describes the serving cert in use and the CA that signed it.
+type CertificatesStatus struct {
+	// CA describes the CA that signed the serving cert.
+	// +optional
+	CA CertificateStatus `json:"ca,omitempty"`
+
+	// Serving describes the serving cert of the webhook server.
+	// +optional
+	Serving CertificateStatus `json:"serving,omitempty"`
+
+	// LastRotationTime is the time the operator last observed a new serving cert.
+	// +optional
+	LastRotationTime metav1.Time `json:"lastRotationTime,omitempty"`
+}
+
+// CertificateStatus describes an x509 certificate.
+type CertificateStatus struct {
+	// SerialNumber is the hex encoded serial number of the cert.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// NotBefore is the start of the validity window of the cert.
+	// +optional
+	NotBefore metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the expiry of the cert.
+	// +optional
+	NotAfter metav1.Time `json:"notAfter,omitempty"`
+
+	// DNSNames are the DNS subject alternative names of the cert.
+	// +optional
+	// +listType=atomic
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// IPAddresses are the IP address subject alternative names of the cert.
+	// +optional
+	// +listType=atomic
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// KeyAlgorithm is the algorithm and size of the public key of the cert,
+	// for example ECDSA-P256 or RSA-3072.
+	// +optional
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+}
+
+// CertRotationPhase is a phase of the staged rotation of the serving certs.
+type CertRotationPhase string
+
+const (
+	CertRotationPhaseTrustingNewCA         CertRotationPhase = "TrustingNewCA"
+	CertRotationPhaseRollingOutServingCert CertRotationPhase = "RollingOutServingCert"
+)
+
 type RunOnceDurationOverrideResourceHash struct {
 	Configuration  string `json:"configuration,omitempty"`
 	ServingCert    string `json:"servingCert,omitempty"`
 	ObservedConfig string `json:"observedConfig,omitempty"`
+
+	// OperandPlacement is the hash of spec.operandPlacement, empty when it is not set.
+	// +optional
+	OperandPlacement string `json:"operandPlacement,omitempty"`
 }
 
 type RunOnceDurationOverrideResources struct {
@@ -111,6 +441,14 @@ type RunOnceDurationOverrideResources struct {
 	// APiServiceRef points to the APIService object related to the RunOnceDurationOverride
 	// admission webhook server.
 	MutatingWebhookConfigurationRef *corev1.ObjectReference `json:"mutatingWebhookConfigurationRef,omitempty"`
+
+	// MutatingAdmissionPolicyRef points to the MutatingAdmissionPolicy object
+	// generated in the AdmissionPolicy deployment mode.
+	MutatingAdmissionPolicyRef *corev1.ObjectReference `json:"mutatingAdmissionPolicyRef,omitempty"`
+
+	// MutatingAdmissionPolicyBindingRef points to the MutatingAdmissionPolicyBinding
+	// object generated in the AdmissionPolicy deployment mode.
+	MutatingAdmissionPolicyBindingRef *corev1.ObjectReference `json:"mutatingAdmissionPolicyBindingRef,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
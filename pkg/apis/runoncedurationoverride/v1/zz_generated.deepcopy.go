@@ -7,9 +7,149 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertRotationStatus) DeepCopyInto(out *CertRotationStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	in.LastCompletionTime.DeepCopyInto(&out.LastCompletionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertRotationStatus.
+func (in *CertRotationStatus) DeepCopy() *CertRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateConfig) DeepCopyInto(out *CertificateConfig) {
+	*out = *in
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RotationThreshold != nil {
+		in, out := &in.RotationThreshold, &out.RotationThreshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateConfig.
+func (in *CertificateConfig) DeepCopy() *CertificateConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
+	*out = *in
+	in.NotBefore.DeepCopyInto(&out.NotBefore)
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
+	*out = *in
+	in.CA.DeepCopyInto(&out.CA)
+	in.Serving.DeepCopyInto(&out.Serving)
+	in.LastRotationTime.DeepCopyInto(&out.LastRotationTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
+func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceActiveDeadlineOverride) DeepCopyInto(out *NamespaceActiveDeadlineOverride) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceActiveDeadlineOverride.
+func (in *NamespaceActiveDeadlineOverride) DeepCopy() *NamespaceActiveDeadlineOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceActiveDeadlineOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperandPlacement) DeepCopyInto(out *OperandPlacement) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperandPlacement.
+func (in *OperandPlacement) DeepCopy() *OperandPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(OperandPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunOnceDurationOverride) DeepCopyInto(out *RunOnceDurationOverride) {
 	*out = *in
@@ -42,7 +182,7 @@ func (in *RunOnceDurationOverride) DeepCopyObject() runtime.Object {
 func (in *RunOnceDurationOverrideConfig) DeepCopyInto(out *RunOnceDurationOverrideConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
@@ -67,6 +207,13 @@ func (in *RunOnceDurationOverrideConfig) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunOnceDurationOverrideConfigSpec) DeepCopyInto(out *RunOnceDurationOverrideConfigSpec) {
 	*out = *in
+	if in.NamespaceOverrides != nil {
+		in, out := &in.NamespaceOverrides, &out.NamespaceOverrides
+		*out = make([]NamespaceActiveDeadlineOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -167,6 +314,16 @@ func (in *RunOnceDurationOverrideResources) DeepCopyInto(out *RunOnceDurationOve
 		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
+	if in.MutatingAdmissionPolicyRef != nil {
+		in, out := &in.MutatingAdmissionPolicyRef, &out.MutatingAdmissionPolicyRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+	if in.MutatingAdmissionPolicyBindingRef != nil {
+		in, out := &in.MutatingAdmissionPolicyBindingRef, &out.MutatingAdmissionPolicyBindingRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -184,7 +341,15 @@ func (in *RunOnceDurationOverrideResources) DeepCopy() *RunOnceDurationOverrideR
 func (in *RunOnceDurationOverrideSpec) DeepCopyInto(out *RunOnceDurationOverrideSpec) {
 	*out = *in
 	in.OperatorSpec.DeepCopyInto(&out.OperatorSpec)
-	out.RunOnceDurationOverrideConfig = in.RunOnceDurationOverrideConfig
+	in.RunOnceDurationOverrideConfig.DeepCopyInto(&out.RunOnceDurationOverrideConfig)
+	in.Webhook.DeepCopyInto(&out.Webhook)
+	if in.UserProvidedCert != nil {
+		in, out := &in.UserProvidedCert, &out.UserProvidedCert
+		*out = new(UserProvidedCertConfig)
+		**out = **in
+	}
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.OperandPlacement.DeepCopyInto(&out.OperandPlacement)
 	return
 }
 
@@ -205,6 +370,8 @@ func (in *RunOnceDurationOverrideStatus) DeepCopyInto(out *RunOnceDurationOverri
 	in.Resources.DeepCopyInto(&out.Resources)
 	out.Hash = in.Hash
 	in.CertsRotateAt.DeepCopyInto(&out.CertsRotateAt)
+	in.CertRotation.DeepCopyInto(&out.CertRotation)
+	in.Certificates.DeepCopyInto(&out.Certificates)
 	return
 }
 
@@ -217,3 +384,45 @@ func (in *RunOnceDurationOverrideStatus) DeepCopy() *RunOnceDurationOverrideStat
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProvidedCertConfig) DeepCopyInto(out *UserProvidedCertConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProvidedCertConfig.
+func (in *UserProvidedCertConfig) DeepCopy() *UserProvidedCertConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProvidedCertConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookConfig) DeepCopyInto(out *WebhookConfig) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookConfig.
+func (in *WebhookConfig) DeepCopy() *WebhookConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,135 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourcehelper"
+)
+
+// FieldManager is the field manager the operator applies its operand
+// resources with.
+const FieldManager = "runoncedurationoverride-operator"
+
+// Object is an API object of a kind the operator applies.
+type Object interface {
+	runtime.Object
+	metav1.Object
+}
+
+// kind describes how objects of a kind are read and applied through the typed
+// client, and how the fields owned by a field manager are extracted from them.
+type kind[T Object, A any] struct {
+	get   func(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	apply func(ctx context.Context, desired A, opts metav1.ApplyOptions) (T, error)
+	// extract is nil for kinds without a schema to extract the fields from,
+	// these are applied on every call.
+	extract func(existing T, fieldManager string) (A, error)
+}
+
+// applyObject applies the fields set on required through server-side apply.
+// The request is skipped when the fields the operator owns already match, and
+// the live generation is the expected one, unless expectedGeneration is -1.
+//
+// Fields set by other field managers that the operator does not set are left
+// untouched. When another field manager owns a field the operator sets, the
+// conflict is reported and the operator takes the field over.
+func applyObject[T Object, A any](ctx context.Context, recorder events.Recorder, k kind[T, A], required T, desired A, expectedGeneration int64) (actual T, modified bool, err error) {
+	if err = toApplyConfiguration(required, desired); err != nil {
+		return
+	}
+
+	existing, err := k.get(ctx, required.GetName(), metav1.GetOptions{})
+	exists := err == nil
+	switch {
+	case k8serrors.IsNotFound(err):
+	case err != nil:
+		return
+	case k.extract != nil:
+		applied, extractErr := k.extract(existing, FieldManager)
+		if extractErr != nil {
+			err = extractErr
+			return
+		}
+
+		if equality.Semantic.DeepEqual(applied, desired) && (expectedGeneration < 0 || existing.GetGeneration() == expectedGeneration) {
+			actual = existing
+			return
+		}
+	}
+
+	actual, err = k.apply(ctx, desired, metav1.ApplyOptions{FieldManager: FieldManager})
+	if k8serrors.IsConflict(err) {
+		reportConflict(recorder, required, err)
+		actual, err = k.apply(ctx, desired, metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
+	}
+
+	if !exists {
+		resourcehelper.ReportCreateEvent(recorder, required, err)
+		modified = err == nil
+		return
+	}
+
+	// Without the fields to compare, tell an update from a no-op apply by the
+	// resource version, the API server does not write a no-op.
+	if err == nil && k.extract == nil && actual.GetResourceVersion() == existing.GetResourceVersion() {
+		actual = existing
+		return
+	}
+
+	resourcehelper.ReportUpdateEvent(recorder, required, err)
+	modified = err == nil
+	return
+}
+
+// toApplyConfiguration copies the fields set on the given object into the
+// given apply configuration. The status and the metadata set by the API server
+// are left out, the operator does not own them.
+func toApplyConfiguration(object runtime.Object, out any) error {
+	var content map[string]interface{}
+	if u, ok := object.(runtime.Unstructured); ok {
+		content = runtime.DeepCopyJSON(u.UnstructuredContent())
+	} else {
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(object); err != nil {
+			return err
+		}
+	}
+
+	removeServerFields(content)
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+// removeServerFields removes the status and the metadata set by the API server
+// from the given object content.
+func removeServerFields(content map[string]interface{}) {
+	unstructured.RemoveNestedField(content, "status")
+	for _, field := range []string{"creationTimestamp", "deletionTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"} {
+		unstructured.RemoveNestedField(content, "metadata", field)
+	}
+}
+
+// reportConflict records a Warning event for the fields of the given object
+// that the operator takes over from another field manager.
+func reportConflict(recorder events.Recorder, object runtime.Object, err error) {
+	gvk := resourcehelper.GuessObjectGroupVersionKind(object)
+	name := resourcehelper.FormatResourceForCLIWithNamespace(object)
+
+	klog.Warningf("resource=%s conflicts with another field manager, forcing the apply - %s", name, err.Error())
+	recorder.Warningf(fmt.Sprintf("%sApplyConflict", gvk.Kind), "Took over fields of %s from another field manager: %v", name, err)
+}
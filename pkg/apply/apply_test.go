@@ -0,0 +1,168 @@
+package apply
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/clock"
+
+	applyfake "github.com/openshift/run-once-duration-override-operator/pkg/apply/fake"
+)
+
+func newConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test-namespace",
+			Labels:    map[string]string{"owner": "operator"},
+		},
+		Data: data,
+	}
+}
+
+func countEvents(recorder events.InMemoryRecorder, reason string) int {
+	count := 0
+	for _, event := range recorder.Events() {
+		if event.Reason == reason {
+			count++
+		}
+	}
+	return count
+}
+
+func countPatches(client *kubefake.Clientset) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "patch" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestConfigMap(t *testing.T) {
+	ctx := context.Background()
+	client := kubefake.NewClientset()
+	recorder := events.NewInMemoryRecorder("test", clock.RealClock{})
+
+	actual, modified, err := ConfigMap(ctx, client.CoreV1(), recorder, newConfigMap(map[string]string{"a": "1", "b": "2"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !modified || actual.Data["a"] != "1" || actual.Data["b"] != "2" {
+		t.Fatalf("expected the ConfigMap to be created, got modified=%t data=%v", modified, actual.Data)
+	}
+	if countEvents(recorder, "ConfigMapCreated") != 1 {
+		t.Errorf("expected a ConfigMapCreated event, got %v", recorder.Events())
+	}
+
+	// Nothing changed, the apply is skipped.
+	patches := countPatches(client)
+	if _, modified, err = ConfigMap(ctx, client.CoreV1(), recorder, newConfigMap(map[string]string{"a": "1", "b": "2"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if modified || countPatches(client) != patches {
+		t.Errorf("expected no apply for an unchanged ConfigMap, got modified=%t", modified)
+	}
+
+	// Another controller labels the ConfigMap.
+	other := corev1ac.ConfigMap("test", "test-namespace").WithLabels(map[string]string{"other": "true"})
+	if _, err := client.CoreV1().ConfigMaps("test-namespace").Apply(ctx, other, metav1.ApplyOptions{FieldManager: "other"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual, modified, err = ConfigMap(ctx, client.CoreV1(), recorder, newConfigMap(map[string]string{"a": "3"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !modified || countEvents(recorder, "ConfigMapUpdated") != 1 {
+		t.Errorf("expected a ConfigMapUpdated event, got %v", recorder.Events())
+	}
+	if actual.Data["a"] != "3" {
+		t.Errorf("expected a=3, got %v", actual.Data)
+	}
+	if _, ok := actual.Data["b"]; ok {
+		t.Errorf("expected the key the operator no longer sets to be removed, got %v", actual.Data)
+	}
+	if actual.Labels["other"] != "true" || actual.Labels["owner"] != "operator" {
+		t.Errorf("expected the labels of both field managers, got %v", actual.Labels)
+	}
+}
+
+func TestConfigMapConflict(t *testing.T) {
+	ctx := context.Background()
+	client := kubefake.NewClientset()
+	recorder := events.NewInMemoryRecorder("test", clock.RealClock{})
+
+	if _, _, err := ConfigMap(ctx, client.CoreV1(), recorder, newConfigMap(map[string]string{"a": "1"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Another field manager forces its own value of a field the operator sets.
+	other := corev1ac.ConfigMap("test", "test-namespace").WithData(map[string]string{"a": "other"})
+	if _, err := client.CoreV1().ConfigMaps("test-namespace").Apply(ctx, other, metav1.ApplyOptions{FieldManager: "other", Force: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual, modified, err := ConfigMap(ctx, client.CoreV1(), recorder, newConfigMap(map[string]string{"a": "1"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !modified || actual.Data["a"] != "1" {
+		t.Errorf("expected the operator to take the field back, got modified=%t data=%v", modified, actual.Data)
+	}
+	if countEvents(recorder, "ConfigMapApplyConflict") != 1 {
+		t.Errorf("expected a ConfigMapApplyConflict event, got %v", recorder.Events())
+	}
+}
+
+func TestUnstructured(t *testing.T) {
+	ctx := context.Background()
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("monitoring.coreos.com/v1")
+	existing.SetKind("ServiceMonitor")
+	existing.SetName("test")
+	existing.SetNamespace("test-namespace")
+	existing.SetResourceVersion("1")
+	client := applyfake.NewSimpleDynamicClient(runtime.NewScheme(), existing)
+	recorder := events.NewInMemoryRecorder("test", clock.RealClock{})
+
+	required := existing.DeepCopy()
+	required.SetResourceVersion("")
+	required.Object["spec"] = map[string]interface{}{"jobLabel": "app"}
+
+	actual, modified, err := ServiceMonitor(ctx, client, recorder, required)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !modified {
+		t.Errorf("expected the ServiceMonitor to be updated")
+	}
+	if label, _, _ := unstructured.NestedString(actual.Object, "spec", "jobLabel"); label != "app" {
+		t.Errorf("expected spec.jobLabel=app, got %v", actual.Object)
+	}
+
+	var patched bool
+	for _, action := range client.Actions() {
+		if patch, ok := action.(interface{ GetPatchType() types.PatchType }); ok && patch.GetPatchType() == types.ApplyPatchType {
+			patched = true
+		}
+	}
+	if !patched {
+		t.Errorf("expected the ServiceMonitor to be applied, got %v", client.Actions())
+	}
+
+	// The same object again is a no-op.
+	if _, modified, err = ServiceMonitor(ctx, client, recorder, required); err != nil || modified {
+		t.Errorf("expected a no-op, got modified=%t err=%v", modified, err)
+	}
+}
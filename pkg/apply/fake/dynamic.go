@@ -0,0 +1,85 @@
+// Package fake provides fake clients that serve the server-side apply requests
+// of the operator in tests.
+package fake
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubetesting "k8s.io/client-go/testing"
+)
+
+// NewSimpleDynamicClient returns a fake dynamic client like
+// dynamicfake.NewSimpleDynamicClient does. The tracker of the latter cannot
+// apply unstructured objects, apply requests are served as merge patches
+// instead, without field ownership.
+func NewSimpleDynamicClient(scheme *runtime.Scheme, objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	client := dynamicfake.NewSimpleDynamicClient(scheme, objects...)
+	client.PrependReactor("patch", "*", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		patch, ok := action.(kubetesting.PatchActionImpl)
+		if !ok || patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+
+		applied := &unstructured.Unstructured{}
+		if err := applied.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+
+		tracker := client.Tracker()
+		gvr, namespace, name := patch.GetResource(), patch.GetNamespace(), patch.GetName()
+		existing, err := tracker.Get(gvr, namespace, name)
+		switch {
+		case k8serrors.IsNotFound(err):
+			err = tracker.Create(gvr, applied, namespace)
+		case err != nil:
+		default:
+			content := existing.(runtime.Unstructured).UnstructuredContent()
+			merged := &unstructured.Unstructured{Object: merge(runtime.DeepCopyJSON(content), applied.Object)}
+			if equality.Semantic.DeepEqual(content, merged.Object) {
+				// Like the API server, do not write a no-op.
+				break
+			}
+
+			// Unlike the API server, the tracker does not bump the resource
+			// version, the apply needs it to tell an update from a no-op.
+			version, _ := strconv.Atoi(merged.GetResourceVersion())
+			merged.SetResourceVersion(strconv.Itoa(version + 1))
+			err = tracker.Update(gvr, merged, namespace)
+		}
+		if err != nil {
+			return true, nil, err
+		}
+
+		object, err := tracker.Get(gvr, namespace, name)
+		return true, object, err
+	})
+
+	return client
+}
+
+// merge merges patch into the given object content the way a JSON merge patch
+// does.
+func merge(content, patch map[string]interface{}) map[string]interface{} {
+	for key, value := range patch {
+		switch value := value.(type) {
+		case nil:
+			delete(content, key)
+		case map[string]interface{}:
+			nested, ok := content[key].(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+			}
+			content[key] = merge(nested, value)
+		default:
+			content[key] = value
+		}
+	}
+
+	return content
+}
@@ -0,0 +1,130 @@
+package apply
+
+import (
+	"context"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	admissionregistrationv1ac "k8s.io/client-go/applyconfigurations/admissionregistration/v1"
+	admissionregistrationv1beta1ac "k8s.io/client-go/applyconfigurations/admissionregistration/v1beta1"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	rbacv1ac "k8s.io/client-go/applyconfigurations/rbac/v1"
+	admissionregistrationv1client "k8s.io/client-go/kubernetes/typed/admissionregistration/v1"
+	admissionregistrationv1beta1client "k8s.io/client-go/kubernetes/typed/admissionregistration/v1beta1"
+	appsv1client "k8s.io/client-go/kubernetes/typed/apps/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	rbacv1client "k8s.io/client-go/kubernetes/typed/rbac/v1"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+)
+
+// ConfigMap applies the given ConfigMap.
+func ConfigMap(ctx context.Context, client corev1client.ConfigMapsGetter, recorder events.Recorder, required *corev1.ConfigMap) (*corev1.ConfigMap, bool, error) {
+	c := client.ConfigMaps(required.Namespace)
+	k := kind[*corev1.ConfigMap, *corev1ac.ConfigMapApplyConfiguration]{get: c.Get, apply: c.Apply, extract: corev1ac.ExtractConfigMap}
+	return applyObject(ctx, recorder, k, required, corev1ac.ConfigMap(required.Name, required.Namespace), -1)
+}
+
+// Secret applies the given Secret.
+func Secret(ctx context.Context, client corev1client.SecretsGetter, recorder events.Recorder, required *corev1.Secret) (*corev1.Secret, bool, error) {
+	c := client.Secrets(required.Namespace)
+	k := kind[*corev1.Secret, *corev1ac.SecretApplyConfiguration]{get: c.Get, apply: c.Apply, extract: corev1ac.ExtractSecret}
+	return applyObject(ctx, recorder, k, required, corev1ac.Secret(required.Name, required.Namespace), -1)
+}
+
+// Service applies the given Service.
+func Service(ctx context.Context, client corev1client.ServicesGetter, recorder events.Recorder, required *corev1.Service) (*corev1.Service, bool, error) {
+	c := client.Services(required.Namespace)
+	k := kind[*corev1.Service, *corev1ac.ServiceApplyConfiguration]{get: c.Get, apply: c.Apply, extract: corev1ac.ExtractService}
+	return applyObject(ctx, recorder, k, required, corev1ac.Service(required.Name, required.Namespace), -1)
+}
+
+// ServiceAccount applies the given ServiceAccount.
+func ServiceAccount(ctx context.Context, client corev1client.ServiceAccountsGetter, recorder events.Recorder, required *corev1.ServiceAccount) (*corev1.ServiceAccount, bool, error) {
+	c := client.ServiceAccounts(required.Namespace)
+	k := kind[*corev1.ServiceAccount, *corev1ac.ServiceAccountApplyConfiguration]{get: c.Get, apply: c.Apply, extract: corev1ac.ExtractServiceAccount}
+	return applyObject(ctx, recorder, k, required, corev1ac.ServiceAccount(required.Name, required.Namespace), -1)
+}
+
+// Role applies the given Role.
+func Role(ctx context.Context, client rbacv1client.RolesGetter, recorder events.Recorder, required *rbacv1.Role) (*rbacv1.Role, bool, error) {
+	c := client.Roles(required.Namespace)
+	k := kind[*rbacv1.Role, *rbacv1ac.RoleApplyConfiguration]{get: c.Get, apply: c.Apply, extract: rbacv1ac.ExtractRole}
+	return applyObject(ctx, recorder, k, required, rbacv1ac.Role(required.Name, required.Namespace), -1)
+}
+
+// RoleBinding applies the given RoleBinding.
+func RoleBinding(ctx context.Context, client rbacv1client.RoleBindingsGetter, recorder events.Recorder, required *rbacv1.RoleBinding) (*rbacv1.RoleBinding, bool, error) {
+	c := client.RoleBindings(required.Namespace)
+	k := kind[*rbacv1.RoleBinding, *rbacv1ac.RoleBindingApplyConfiguration]{get: c.Get, apply: c.Apply, extract: rbacv1ac.ExtractRoleBinding}
+	return applyObject(ctx, recorder, k, required, rbacv1ac.RoleBinding(required.Name, required.Namespace), -1)
+}
+
+// ClusterRole applies the given ClusterRole.
+func ClusterRole(ctx context.Context, client rbacv1client.ClusterRolesGetter, recorder events.Recorder, required *rbacv1.ClusterRole) (*rbacv1.ClusterRole, bool, error) {
+	c := client.ClusterRoles()
+	k := kind[*rbacv1.ClusterRole, *rbacv1ac.ClusterRoleApplyConfiguration]{get: c.Get, apply: c.Apply, extract: rbacv1ac.ExtractClusterRole}
+	return applyObject(ctx, recorder, k, required, rbacv1ac.ClusterRole(required.Name), -1)
+}
+
+// ClusterRoleBinding applies the given ClusterRoleBinding.
+func ClusterRoleBinding(ctx context.Context, client rbacv1client.ClusterRoleBindingsGetter, recorder events.Recorder, required *rbacv1.ClusterRoleBinding) (*rbacv1.ClusterRoleBinding, bool, error) {
+	c := client.ClusterRoleBindings()
+	k := kind[*rbacv1.ClusterRoleBinding, *rbacv1ac.ClusterRoleBindingApplyConfiguration]{get: c.Get, apply: c.Apply, extract: rbacv1ac.ExtractClusterRoleBinding}
+	return applyObject(ctx, recorder, k, required, rbacv1ac.ClusterRoleBinding(required.Name), -1)
+}
+
+// DaemonSet applies the given DaemonSet. It is applied again when its
+// generation is not the expected one, unless expectedGeneration is -1.
+func DaemonSet(ctx context.Context, client appsv1client.DaemonSetsGetter, recorder events.Recorder, required *appsv1.DaemonSet, expectedGeneration int64) (*appsv1.DaemonSet, bool, error) {
+	c := client.DaemonSets(required.Namespace)
+	k := kind[*appsv1.DaemonSet, *appsv1ac.DaemonSetApplyConfiguration]{get: c.Get, apply: c.Apply, extract: appsv1ac.ExtractDaemonSet}
+	return applyObject(ctx, recorder, k, required, appsv1ac.DaemonSet(required.Name, required.Namespace), expectedGeneration)
+}
+
+// Deployment applies the given Deployment. It is applied again when its
+// generation is not the expected one, unless expectedGeneration is -1.
+func Deployment(ctx context.Context, client appsv1client.DeploymentsGetter, recorder events.Recorder, required *appsv1.Deployment, expectedGeneration int64) (*appsv1.Deployment, bool, error) {
+	c := client.Deployments(required.Namespace)
+	k := kind[*appsv1.Deployment, *appsv1ac.DeploymentApplyConfiguration]{get: c.Get, apply: c.Apply, extract: appsv1ac.ExtractDeployment}
+	return applyObject(ctx, recorder, k, required, appsv1ac.Deployment(required.Name, required.Namespace), expectedGeneration)
+}
+
+// MutatingWebhookConfiguration applies the given MutatingWebhookConfiguration.
+func MutatingWebhookConfiguration(ctx context.Context, client admissionregistrationv1client.MutatingWebhookConfigurationsGetter, recorder events.Recorder, required *admissionregistrationv1.MutatingWebhookConfiguration) (*admissionregistrationv1.MutatingWebhookConfiguration, bool, error) {
+	c := client.MutatingWebhookConfigurations()
+	k := kind[*admissionregistrationv1.MutatingWebhookConfiguration, *admissionregistrationv1ac.MutatingWebhookConfigurationApplyConfiguration]{get: c.Get, apply: c.Apply, extract: admissionregistrationv1ac.ExtractMutatingWebhookConfiguration}
+	return applyObject(ctx, recorder, k, required, admissionregistrationv1ac.MutatingWebhookConfiguration(required.Name), -1)
+}
+
+// MutatingAdmissionPolicy applies the given MutatingAdmissionPolicy.
+func MutatingAdmissionPolicy(ctx context.Context, client admissionregistrationv1beta1client.MutatingAdmissionPoliciesGetter, recorder events.Recorder, required *admissionregistrationv1beta1.MutatingAdmissionPolicy) (*admissionregistrationv1beta1.MutatingAdmissionPolicy, bool, error) {
+	c := client.MutatingAdmissionPolicies()
+	k := kind[*admissionregistrationv1beta1.MutatingAdmissionPolicy, *admissionregistrationv1beta1ac.MutatingAdmissionPolicyApplyConfiguration]{get: c.Get, apply: c.Apply, extract: admissionregistrationv1beta1ac.ExtractMutatingAdmissionPolicy}
+	return applyObject(ctx, recorder, k, required, admissionregistrationv1beta1ac.MutatingAdmissionPolicy(required.Name), -1)
+}
+
+// MutatingAdmissionPolicyBinding applies the given MutatingAdmissionPolicyBinding.
+func MutatingAdmissionPolicyBinding(ctx context.Context, client admissionregistrationv1beta1client.MutatingAdmissionPolicyBindingsGetter, recorder events.Recorder, required *admissionregistrationv1beta1.MutatingAdmissionPolicyBinding) (*admissionregistrationv1beta1.MutatingAdmissionPolicyBinding, bool, error) {
+	c := client.MutatingAdmissionPolicyBindings()
+	k := kind[*admissionregistrationv1beta1.MutatingAdmissionPolicyBinding, *admissionregistrationv1beta1ac.MutatingAdmissionPolicyBindingApplyConfiguration]{get: c.Get, apply: c.Apply, extract: admissionregistrationv1beta1ac.ExtractMutatingAdmissionPolicyBinding}
+	return applyObject(ctx, recorder, k, required, admissionregistrationv1beta1ac.MutatingAdmissionPolicyBinding(required.Name), -1)
+}
+
+// ValidatingAdmissionPolicy applies the given ValidatingAdmissionPolicy.
+func ValidatingAdmissionPolicy(ctx context.Context, client admissionregistrationv1client.ValidatingAdmissionPoliciesGetter, recorder events.Recorder, required *admissionregistrationv1.ValidatingAdmissionPolicy) (*admissionregistrationv1.ValidatingAdmissionPolicy, bool, error) {
+	c := client.ValidatingAdmissionPolicies()
+	k := kind[*admissionregistrationv1.ValidatingAdmissionPolicy, *admissionregistrationv1ac.ValidatingAdmissionPolicyApplyConfiguration]{get: c.Get, apply: c.Apply, extract: admissionregistrationv1ac.ExtractValidatingAdmissionPolicy}
+	return applyObject(ctx, recorder, k, required, admissionregistrationv1ac.ValidatingAdmissionPolicy(required.Name), -1)
+}
+
+// ValidatingAdmissionPolicyBinding applies the given ValidatingAdmissionPolicyBinding.
+func ValidatingAdmissionPolicyBinding(ctx context.Context, client admissionregistrationv1client.ValidatingAdmissionPolicyBindingsGetter, recorder events.Recorder, required *admissionregistrationv1.ValidatingAdmissionPolicyBinding) (*admissionregistrationv1.ValidatingAdmissionPolicyBinding, bool, error) {
+	c := client.ValidatingAdmissionPolicyBindings()
+	k := kind[*admissionregistrationv1.ValidatingAdmissionPolicyBinding, *admissionregistrationv1ac.ValidatingAdmissionPolicyBindingApplyConfiguration]{get: c.Get, apply: c.Apply, extract: admissionregistrationv1ac.ExtractValidatingAdmissionPolicyBinding}
+	return applyObject(ctx, recorder, k, required, admissionregistrationv1ac.ValidatingAdmissionPolicyBinding(required.Name), -1)
+}
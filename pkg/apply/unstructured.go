@@ -0,0 +1,50 @@
+package apply
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+)
+
+var (
+	ServiceMonitorGVR = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "servicemonitors"}
+	PrometheusRuleGVR = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "prometheusrules"}
+
+	// The v1 MutatingAdmissionPolicy types are not vendored yet, the v1 API
+	// has the same schema as v1beta1.
+	MutatingAdmissionPolicyV1GVR        = schema.GroupVersionResource{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "mutatingadmissionpolicies"}
+	MutatingAdmissionPolicyBindingV1GVR = schema.GroupVersionResource{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "mutatingadmissionpolicybindings"}
+)
+
+// ServiceMonitor applies the given ServiceMonitor.
+func ServiceMonitor(ctx context.Context, client dynamic.Interface, recorder events.Recorder, required *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+	return Unstructured(ctx, client, recorder, ServiceMonitorGVR, required)
+}
+
+// PrometheusRule applies the given PrometheusRule.
+func PrometheusRule(ctx context.Context, client dynamic.Interface, recorder events.Recorder, required *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+	return Unstructured(ctx, client, recorder, PrometheusRuleGVR, required)
+}
+
+// Unstructured applies the given object of the given resource. There is no
+// schema to extract the fields the operator owns from the live object with,
+// so the object is applied on every call. A no-op apply is not written by the
+// API server.
+func Unstructured(ctx context.Context, client dynamic.Interface, recorder events.Recorder, resource schema.GroupVersionResource, required *unstructured.Unstructured) (*unstructured.Unstructured, bool, error) {
+	c := client.Resource(resource).Namespace(required.GetNamespace())
+	k := kind[*unstructured.Unstructured, *unstructured.Unstructured]{
+		get: func(ctx context.Context, name string, opts metav1.GetOptions) (*unstructured.Unstructured, error) {
+			return c.Get(ctx, name, opts)
+		},
+		apply: func(ctx context.Context, desired *unstructured.Unstructured, opts metav1.ApplyOptions) (*unstructured.Unstructured, error) {
+			return c.Apply(ctx, desired.GetName(), desired, opts)
+		},
+	}
+
+	return applyObject(ctx, recorder, k, required, &unstructured.Unstructured{}, -1)
+}
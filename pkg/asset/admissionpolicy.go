@@ -0,0 +1,235 @@
+package asset
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+)
+
+const (
+	// AuditAnnotationKey is set on pods in Audit mode with the activeDeadlineSeconds
+	// value that would have been applied.
+	AuditAnnotationKey = "runoncedurationoverride.openshift.io/active-deadline-seconds"
+
+	namespaceLabels    = "namespaceObject.metadata.labels"
+	hasNamespaceLabels = "has(namespaceObject.metadata.labels)"
+
+	// Mirrors the webhook: only pods with restartPolicy Never or OnFailure are
+	// considered, and activeDeadlineSeconds is only ever lowered.
+	runOncePodExpression     = "has(object.spec.restartPolicy) && object.spec.restartPolicy in ['Never', 'OnFailure']"
+	lowersDeadlineExpression = "variables.activeDeadlineSeconds > 0 && (!has(object.spec.activeDeadlineSeconds) || object.spec.activeDeadlineSeconds > variables.activeDeadlineSeconds)"
+)
+
+func (a *Asset) MutatingAdmissionPolicy() *mutatingAdmissionPolicy {
+	return &mutatingAdmissionPolicy{
+		values: a.values,
+	}
+}
+
+type mutatingAdmissionPolicy struct {
+	values        *Values
+	spec          appsv1.RunOnceDurationOverrideConfigSpec
+	failurePolicy admissionregistrationv1.FailurePolicyType
+}
+
+func (m *mutatingAdmissionPolicy) Name() string {
+	return fmt.Sprintf("%s.%s", m.values.AdmissionAPIResource, m.values.AdmissionAPIGroup)
+}
+
+// WithConfiguration sets the configuration the policy expressions are generated from.
+func (m *mutatingAdmissionPolicy) WithConfiguration(spec appsv1.RunOnceDurationOverrideConfigSpec) *mutatingAdmissionPolicy {
+	m.spec = spec
+	return m
+}
+
+// WithFailurePolicy sets the failure policy of the policy, an empty one keeps
+// the default of Fail.
+func (m *mutatingAdmissionPolicy) WithFailurePolicy(failurePolicy admissionregistrationv1.FailurePolicyType) *mutatingAdmissionPolicy {
+	m.failurePolicy = failurePolicy
+	return m
+}
+
+func (m *mutatingAdmissionPolicy) New() *admissionregistrationv1beta1.MutatingAdmissionPolicy {
+	policy := admissionregistrationv1beta1.Fail
+	if m.failurePolicy != "" {
+		policy = admissionregistrationv1beta1.FailurePolicyType(m.failurePolicy)
+	}
+	matchPolicy := admissionregistrationv1beta1.Equivalent
+	scope := admissionregistrationv1beta1.AllScopes
+
+	mutation := "Object{spec: Object.spec{activeDeadlineSeconds: variables.activeDeadlineSeconds}}"
+	if m.spec.GetMode() == appsv1.OverrideModeAudit {
+		mutation = fmt.Sprintf("Object{metadata: Object.metadata{annotations: {%q: string(variables.activeDeadlineSeconds)}}}", AuditAnnotationKey)
+	}
+
+	return &admissionregistrationv1beta1.MutatingAdmissionPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MutatingAdmissionPolicy",
+			APIVersion: "admissionregistration.k8s.io/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: m.Name(),
+			Labels: map[string]string{
+				m.values.OwnerLabelKey: m.values.OwnerLabelValue,
+			},
+		},
+		Spec: admissionregistrationv1beta1.MutatingAdmissionPolicySpec{
+			MatchConstraints: &admissionregistrationv1beta1.MatchResources{
+				NamespaceSelector: &metav1.LabelSelector{},
+				ObjectSelector:    &metav1.LabelSelector{},
+				MatchPolicy:       &matchPolicy,
+				ResourceRules: []admissionregistrationv1beta1.NamedRuleWithOperations{
+					{
+						RuleWithOperations: admissionregistrationv1beta1.RuleWithOperations{
+							Operations: []admissionregistrationv1beta1.OperationType{
+								admissionregistrationv1beta1.Create,
+								admissionregistrationv1beta1.Update,
+							},
+							Rule: admissionregistrationv1beta1.Rule{
+								APIGroups:   []string{""},
+								APIVersions: []string{"v1"},
+								Resources:   []string{"pods"},
+								Scope:       &scope,
+							},
+						},
+					},
+				},
+			},
+			MatchConditions: []admissionregistrationv1beta1.MatchCondition{
+				{
+					Name:       "run-once-pod",
+					Expression: runOncePodExpression,
+				},
+			},
+			Variables: []admissionregistrationv1beta1.Variable{
+				{
+					Name:       "activeDeadlineSeconds",
+					Expression: m.activeDeadlineSecondsExpression(),
+				},
+			},
+			Mutations: []admissionregistrationv1beta1.Mutation{
+				{
+					PatchType: admissionregistrationv1beta1.PatchTypeApplyConfiguration,
+					ApplyConfiguration: &admissionregistrationv1beta1.ApplyConfiguration{
+						Expression: fmt.Sprintf("%s ? %s : Object{}", lowersDeadlineExpression, mutation),
+					},
+				},
+			},
+			FailurePolicy:      &policy,
+			ReinvocationPolicy: admissionregistrationv1beta1.IfNeededReinvocationPolicy,
+		},
+	}
+}
+
+// activeDeadlineSecondsExpression picks the deadline of the first NamespaceOverrides
+// rule matching the labels of the pod's namespace, or the default one.
+func (m *mutatingAdmissionPolicy) activeDeadlineSecondsExpression() string {
+	expression := strconv.FormatInt(m.spec.ActiveDeadlineSeconds, 10)
+	for i := len(m.spec.NamespaceOverrides) - 1; i >= 0; i-- {
+		rule := m.spec.NamespaceOverrides[i]
+		expression = fmt.Sprintf("%s ? %d : (%s)", labelSelectorExpression(&rule.NamespaceSelector), rule.ActiveDeadlineSeconds, expression)
+	}
+
+	return expression
+}
+
+// labelSelectorExpression translates a label selector into a CEL expression over
+// the labels of the namespace object.
+func labelSelectorExpression(selector *metav1.LabelSelector) string {
+	hasKey := func(key string) string {
+		return fmt.Sprintf("(%s && %q in %s)", hasNamespaceLabels, key, namespaceLabels)
+	}
+
+	terms := []string{}
+	for key, value := range selector.MatchLabels {
+		terms = append(terms, fmt.Sprintf("(%s && %s[%q] == %q)", hasKey(key), namespaceLabels, key, value))
+	}
+	for _, requirement := range selector.MatchExpressions {
+		values := make([]string, 0, len(requirement.Values))
+		for _, value := range requirement.Values {
+			values = append(values, strconv.Quote(value))
+		}
+		list := fmt.Sprintf("[%s]", strings.Join(values, ", "))
+
+		switch requirement.Operator {
+		case metav1.LabelSelectorOpIn:
+			terms = append(terms, fmt.Sprintf("(%s && %s[%q] in %s)", hasKey(requirement.Key), namespaceLabels, requirement.Key, list))
+		case metav1.LabelSelectorOpNotIn:
+			terms = append(terms, fmt.Sprintf("(!%s || !(%s[%q] in %s))", hasKey(requirement.Key), namespaceLabels, requirement.Key, list))
+		case metav1.LabelSelectorOpExists:
+			terms = append(terms, hasKey(requirement.Key))
+		case metav1.LabelSelectorOpDoesNotExist:
+			terms = append(terms, fmt.Sprintf("!%s", hasKey(requirement.Key)))
+		}
+	}
+
+	if len(terms) == 0 {
+		return "true"
+	}
+
+	// Keep the generated expression stable so that it does not show up as a change.
+	sort.Strings(terms[:len(selector.MatchLabels)])
+	return strings.Join(terms, " && ")
+}
+
+func (a *Asset) MutatingAdmissionPolicyBinding() *mutatingAdmissionPolicyBinding {
+	return &mutatingAdmissionPolicyBinding{
+		values: a.values,
+	}
+}
+
+type mutatingAdmissionPolicyBinding struct {
+	values            *Values
+	namespaceSelector *metav1.LabelSelector
+	objectSelector    *metav1.LabelSelector
+}
+
+func (m *mutatingAdmissionPolicyBinding) Name() string {
+	return fmt.Sprintf("%s.%s", m.values.AdmissionAPIResource, m.values.AdmissionAPIGroup)
+}
+
+// WithSelectors sets the namespace and object selectors of the binding, with the
+// same defaults and run-level exclusion as the webhook configuration.
+func (m *mutatingAdmissionPolicyBinding) WithSelectors(namespaceSelector, objectSelector *metav1.LabelSelector) *mutatingAdmissionPolicyBinding {
+	m.namespaceSelector = namespaceSelector
+	m.objectSelector = objectSelector
+	return m
+}
+
+func (m *mutatingAdmissionPolicyBinding) New() *admissionregistrationv1beta1.MutatingAdmissionPolicyBinding {
+	matchPolicy := admissionregistrationv1beta1.Equivalent
+
+	namespaceSelector := (&mutatingWebhookConfiguration{values: m.values}).WithSelectors(m.namespaceSelector, nil).NamespaceSelector()
+	objectSelector := &metav1.LabelSelector{}
+	if m.objectSelector != nil {
+		objectSelector = m.objectSelector.DeepCopy()
+	}
+
+	return &admissionregistrationv1beta1.MutatingAdmissionPolicyBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MutatingAdmissionPolicyBinding",
+			APIVersion: "admissionregistration.k8s.io/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: m.Name(),
+			Labels: map[string]string{
+				m.values.OwnerLabelKey: m.values.OwnerLabelValue,
+			},
+		},
+		Spec: admissionregistrationv1beta1.MutatingAdmissionPolicyBindingSpec{
+			PolicyName: (&mutatingAdmissionPolicy{values: m.values}).Name(),
+			MatchResources: &admissionregistrationv1beta1.MatchResources{
+				NamespaceSelector: namespaceSelector,
+				ObjectSelector:    objectSelector,
+				MatchPolicy:       &matchPolicy,
+			},
+		},
+	}
+}
@@ -25,7 +25,9 @@ func New(context runtime.OperandContext) *Asset {
 		ConfigurationHashAnnotationKey:  fmt.Sprintf("%s.%s/configuration.hash", context.WebhookName(), appsv1.GroupName),
 		ServingCertHashAnnotationKey:    fmt.Sprintf("%s.%s/servingcert.hash", context.WebhookName(), appsv1.GroupName),
 		ObservedConfigHashAnnotationKey: fmt.Sprintf("%s.%s/observedconfig.hash", context.WebhookName(), appsv1.GroupName),
+		PlacementHashAnnotationKey:      fmt.Sprintf("%s.%s/placement.hash", context.WebhookName(), appsv1.GroupName),
 		OwnerAnnotationKey:              fmt.Sprintf("%s.%s/owner", context.WebhookName(), appsv1.GroupName),
+		LogLevelAnnotationKey:           fmt.Sprintf("%s.%s/loglevel", context.WebhookName(), appsv1.GroupName),
 	}
 
 	return &Asset{
@@ -59,5 +61,7 @@ type Values struct {
 	ConfigurationHashAnnotationKey  string
 	ServingCertHashAnnotationKey    string
 	ObservedConfigHashAnnotationKey string
+	PlacementHashAnnotationKey      string
 	OwnerAnnotationKey              string
+	LogLevelAnnotationKey           string
 }
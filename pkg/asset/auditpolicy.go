@@ -0,0 +1,150 @@
+package asset
+
+import (
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+)
+
+// AuditValidatingAdmissionPolicy returns the policy that warns about and audits
+// the pods the override would change, in the Audit mode of the AdmissionPolicy
+// deployment mode. A MutatingAdmissionPolicy cannot return a warning.
+func (a *Asset) AuditValidatingAdmissionPolicy() *auditValidatingAdmissionPolicy {
+	return &auditValidatingAdmissionPolicy{
+		values: a.values,
+	}
+}
+
+type auditValidatingAdmissionPolicy struct {
+	values *Values
+	spec   appsv1.RunOnceDurationOverrideConfigSpec
+}
+
+func (m *auditValidatingAdmissionPolicy) Name() string {
+	return fmt.Sprintf("%s.%s", m.values.AdmissionAPIResource, m.values.AdmissionAPIGroup)
+}
+
+// WithConfiguration sets the configuration the policy expressions are generated from.
+func (m *auditValidatingAdmissionPolicy) WithConfiguration(spec appsv1.RunOnceDurationOverrideConfigSpec) *auditValidatingAdmissionPolicy {
+	m.spec = spec
+	return m
+}
+
+func (m *auditValidatingAdmissionPolicy) New() *admissionregistrationv1.ValidatingAdmissionPolicy {
+	// The policy never denies a pod, the binding only warns and audits.
+	policy := admissionregistrationv1.Ignore
+	matchPolicy := admissionregistrationv1.Equivalent
+	scope := admissionregistrationv1.AllScopes
+
+	return &admissionregistrationv1.ValidatingAdmissionPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ValidatingAdmissionPolicy",
+			APIVersion: "admissionregistration.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: m.Name(),
+			Labels: map[string]string{
+				m.values.OwnerLabelKey: m.values.OwnerLabelValue,
+			},
+		},
+		Spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+			MatchConstraints: &admissionregistrationv1.MatchResources{
+				NamespaceSelector: &metav1.LabelSelector{},
+				ObjectSelector:    &metav1.LabelSelector{},
+				MatchPolicy:       &matchPolicy,
+				ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{
+					{
+						RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+							Operations: []admissionregistrationv1.OperationType{
+								admissionregistrationv1.Create,
+								admissionregistrationv1.Update,
+							},
+							Rule: admissionregistrationv1.Rule{
+								APIGroups:   []string{""},
+								APIVersions: []string{"v1"},
+								Resources:   []string{"pods"},
+								Scope:       &scope,
+							},
+						},
+					},
+				},
+			},
+			MatchConditions: []admissionregistrationv1.MatchCondition{
+				{
+					Name:       "run-once-pod",
+					Expression: runOncePodExpression,
+				},
+			},
+			Variables: []admissionregistrationv1.Variable{
+				{
+					Name:       "activeDeadlineSeconds",
+					Expression: (&mutatingAdmissionPolicy{spec: m.spec}).activeDeadlineSecondsExpression(),
+				},
+			},
+			Validations: []admissionregistrationv1.Validation{
+				{
+					Expression:        fmt.Sprintf("!(%s)", lowersDeadlineExpression),
+					MessageExpression: "'RunOnceDurationOverride would set activeDeadlineSeconds to ' + string(variables.activeDeadlineSeconds)",
+				},
+			},
+			FailurePolicy: &policy,
+		},
+	}
+}
+
+func (a *Asset) AuditValidatingAdmissionPolicyBinding() *auditValidatingAdmissionPolicyBinding {
+	return &auditValidatingAdmissionPolicyBinding{
+		values: a.values,
+	}
+}
+
+type auditValidatingAdmissionPolicyBinding struct {
+	values            *Values
+	namespaceSelector *metav1.LabelSelector
+	objectSelector    *metav1.LabelSelector
+}
+
+func (m *auditValidatingAdmissionPolicyBinding) Name() string {
+	return fmt.Sprintf("%s.%s", m.values.AdmissionAPIResource, m.values.AdmissionAPIGroup)
+}
+
+// WithSelectors sets the namespace and object selectors of the binding, the
+// same as the MutatingAdmissionPolicyBinding.
+func (m *auditValidatingAdmissionPolicyBinding) WithSelectors(namespaceSelector, objectSelector *metav1.LabelSelector) *auditValidatingAdmissionPolicyBinding {
+	m.namespaceSelector = namespaceSelector
+	m.objectSelector = objectSelector
+	return m
+}
+
+func (m *auditValidatingAdmissionPolicyBinding) New() *admissionregistrationv1.ValidatingAdmissionPolicyBinding {
+	matchPolicy := admissionregistrationv1.Equivalent
+	mutating := (&mutatingAdmissionPolicyBinding{values: m.values}).WithSelectors(m.namespaceSelector, m.objectSelector).New()
+
+	return &admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ValidatingAdmissionPolicyBinding",
+			APIVersion: "admissionregistration.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: m.Name(),
+			Labels: map[string]string{
+				m.values.OwnerLabelKey: m.values.OwnerLabelValue,
+			},
+		},
+		Spec: admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{
+			PolicyName: (&auditValidatingAdmissionPolicy{values: m.values}).Name(),
+			MatchResources: &admissionregistrationv1.MatchResources{
+				NamespaceSelector: mutating.Spec.MatchResources.NamespaceSelector,
+				ObjectSelector:    mutating.Spec.MatchResources.ObjectSelector,
+				MatchPolicy:       &matchPolicy,
+			},
+			ValidationActions: []admissionregistrationv1.ValidationAction{
+				admissionregistrationv1.Warn,
+				admissionregistrationv1.Audit,
+			},
+		},
+	}
+}
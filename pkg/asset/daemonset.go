@@ -4,6 +4,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
@@ -23,6 +24,7 @@ func (d *daemonset) Name() string {
 
 func (d *daemonset) New() *appsv1.DaemonSet {
 	tolerationSeconds := int64(120)
+	maxUnavailable, maxSurge := intstr.FromInt32(1), intstr.FromInt32(0)
 	values := d.asset.Values()
 
 	return &appsv1.DaemonSet{
@@ -43,6 +45,17 @@ func (d *daemonset) New() *appsv1.DaemonSet {
 					values.SelectorLabelKey: values.SelectorLabelValue,
 				},
 			},
+			// The pods bind a host port, a surge pod could not be scheduled next
+			// to the one it replaces, so the pods are replaced one node at a time.
+			// The API server reaches the webhook on localhost, the one on the node
+			// being rolled cannot call it until the new pod is ready.
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: &maxUnavailable,
+					MaxSurge:       &maxSurge,
+				},
+			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: d.Name(),
@@ -61,83 +74,16 @@ func (d *daemonset) New() *appsv1.DaemonSet {
 					},
 					ServiceAccountName: values.ServiceAccountName,
 					Containers: []corev1.Container{
-						{
-							Name:                     d.Name(),
-							Image:                    values.OperandImage,
-							ImagePullPolicy:          corev1.PullAlways,
-							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
-							Command: []string{
-								"/usr/bin/run-once-duration-override",
-							},
-							Args: []string{
-								"--secure-port=9448",
-								"--bind-address=127.0.0.1",
-								"--tls-cert-file=/var/serving-cert/tls.crt",
-								"--tls-private-key-file=/var/serving-cert/tls.key",
-								"--v=3",
-							},
-							Env: []corev1.EnvVar{
-								{
-									Name:  "CONFIGURATION_PATH",
-									Value: "/etc/runoncedurationoverride/config/override.yaml",
-								},
-							},
-							Ports: []corev1.ContainerPort{
-								{
-									ContainerPort: 9448,
-									HostPort:      9448,
-									Protocol:      corev1.ProtocolTCP,
-								},
-							},
-							SecurityContext: &corev1.SecurityContext{
-								AllowPrivilegeEscalation: pointer.BoolPtr(false),
-								ReadOnlyRootFilesystem:   pointer.BoolPtr(true),
-								Capabilities: &corev1.Capabilities{
-									Drop: []corev1.Capability{"ALL"},
-								},
-								RunAsNonRoot: pointer.BoolPtr(true),
-								SeccompProfile: &corev1.SeccompProfile{
-									Type: "RuntimeDefault",
-								},
-							},
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      "serving-cert",
-									MountPath: "/var/serving-cert",
-								},
-								{
-									Name:      "configuration",
-									MountPath: "/etc/runoncedurationoverride/config/override.yaml",
-									SubPath:   values.ConfigurationKey,
-								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
-						{
-							Name: "serving-cert",
-							VolumeSource: corev1.VolumeSource{
-								Secret: &corev1.SecretVolumeSource{
-									SecretName: d.asset.ServiceServingSecret().Name(),
-									DefaultMode: func() *int32 {
-										v := int32(420)
-										return &v
-									}(),
-								},
-							},
-						},
-
-						{
-							Name: "configuration",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: d.asset.Configuration().Name(),
-									},
-								},
-							},
-						},
+						d.asset.serverContainer(d.Name(), []string{
+							"--secure-port=9448",
+							"--bind-address=127.0.0.1",
+						}, corev1.ContainerPort{
+							ContainerPort: 9448,
+							HostPort:      9448,
+							Protocol:      corev1.ProtocolTCP,
+						}),
 					},
+					Volumes: d.asset.serverVolumes(),
 					Tolerations: []corev1.Toleration{
 						{
 							Key:      "node-role.kubernetes.io/master",
@@ -162,3 +108,84 @@ func (d *daemonset) New() *appsv1.DaemonSet {
 		},
 	}
 }
+
+// serverContainer returns the admission webhook server container shared by
+// the DaemonSet and the Deployment workloads.
+func (a *Asset) serverContainer(name string, args []string, port corev1.ContainerPort) corev1.Container {
+	values := a.Values()
+
+	return corev1.Container{
+		Name:                     name,
+		Image:                    values.OperandImage,
+		ImagePullPolicy:          corev1.PullAlways,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Command: []string{
+			"/usr/bin/run-once-duration-override",
+		},
+		Args: append(args,
+			"--tls-cert-file=/var/serving-cert/tls.crt",
+			"--tls-private-key-file=/var/serving-cert/tls.key",
+		),
+		Env: []corev1.EnvVar{
+			{
+				Name:  "CONFIGURATION_PATH",
+				Value: "/etc/runoncedurationoverride/config/override.yaml",
+			},
+		},
+		Ports: []corev1.ContainerPort{
+			port,
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			ReadOnlyRootFilesystem:   pointer.BoolPtr(true),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			RunAsNonRoot: pointer.BoolPtr(true),
+			SeccompProfile: &corev1.SeccompProfile{
+				Type: "RuntimeDefault",
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "serving-cert",
+				MountPath: "/var/serving-cert",
+			},
+			{
+				Name:      "configuration",
+				MountPath: "/etc/runoncedurationoverride/config/override.yaml",
+				SubPath:   values.ConfigurationKey,
+			},
+		},
+	}
+}
+
+// serverVolumes returns the serving cert and configuration volumes mounted
+// by serverContainer.
+func (a *Asset) serverVolumes() []corev1.Volume {
+	return []corev1.Volume{
+		{
+			Name: "serving-cert",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: a.ServiceServingSecret().Name(),
+					DefaultMode: func() *int32 {
+						v := int32(420)
+						return &v
+					}(),
+				},
+			},
+		},
+
+		{
+			Name: "configuration",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: a.Configuration().Name(),
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package asset
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// WorkloadLabelKey tells the pods of the Deployment workload apart from the
+	// hostNetwork DaemonSet pods, so that the Service only selects the former.
+	WorkloadLabelKey   = "runoncedurationoverride.openshift.io/workload"
+	WorkloadDeployment = "deployment"
+)
+
+func (a *Asset) Deployment() *deployment {
+	return &deployment{
+		asset: a,
+	}
+}
+
+type deployment struct {
+	asset *Asset
+}
+
+func (d *deployment) Name() string {
+	return d.asset.Values().Name
+}
+
+func (d *deployment) Labels() map[string]string {
+	values := d.asset.Values()
+
+	return map[string]string{
+		values.SelectorLabelKey: values.SelectorLabelValue,
+		WorkloadLabelKey:        WorkloadDeployment,
+	}
+}
+
+func (d *deployment) New() *appsv1.Deployment {
+	values := d.asset.Values()
+	replicas := int32(2)
+	maxUnavailable, maxSurge := intstr.FromInt32(0), intstr.FromInt32(1)
+
+	podLabels := d.Labels()
+	podLabels[values.OwnerLabelKey] = values.OwnerLabelValue
+
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: values.Namespace,
+			Name:      d.Name(),
+			Labels: map[string]string{
+				values.OwnerLabelKey: values.OwnerLabelValue,
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: d.Labels(),
+			},
+			// Start a new replica before an old one is stopped, so that the
+			// webhook never runs below the desired number of replicas.
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: &maxUnavailable,
+					MaxSurge:       &maxSurge,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: d.Name(),
+					Annotations: map[string]string{
+						"openshift.io/required-scc": "restricted-v2",
+					},
+					Labels: podLabels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: values.ServiceAccountName,
+					Containers: []corev1.Container{
+						d.asset.serverContainer(d.Name(), []string{
+							"--secure-port=8443",
+							"--bind-address=0.0.0.0",
+						}, corev1.ContainerPort{
+							ContainerPort: 8443,
+							Protocol:      corev1.ProtocolTCP,
+						}),
+					},
+					Volumes: d.asset.serverVolumes(),
+					Affinity: &corev1.Affinity{
+						// Spread the replicas so that losing a node does not take the webhook down.
+						PodAntiAffinity: &corev1.PodAntiAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+								{
+									Weight: 100,
+									PodAffinityTerm: corev1.PodAffinityTerm{
+										TopologyKey: "kubernetes.io/hostname",
+										LabelSelector: &metav1.LabelSelector{
+											MatchLabels: d.Labels(),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
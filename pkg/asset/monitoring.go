@@ -0,0 +1,180 @@
+package asset
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// OperatorSelectorLabelKey and OperatorSelectorLabelValue select the
+	// operator pods, see the operator Deployment manifest.
+	OperatorSelectorLabelKey   = "runoncedurationoverride.operator"
+	OperatorSelectorLabelValue = "true"
+
+	// OperatorMetricsPort is the port the operator serves /metrics on.
+	OperatorMetricsPort = 8443
+
+	// PrometheusNamespace and PrometheusServiceAccountName identify the
+	// cluster monitoring Prometheus that scrapes the operator.
+	PrometheusNamespace          = "openshift-monitoring"
+	PrometheusServiceAccountName = "prometheus-k8s"
+)
+
+// Monitoring returns the assets that have cluster monitoring scrape the
+// operator metrics.
+func (a *Asset) Monitoring() *monitoring {
+	return &monitoring{
+		asset: a,
+	}
+}
+
+type monitoring struct {
+	asset *Asset
+}
+
+func (m *monitoring) MetricsServiceName() string {
+	return fmt.Sprintf("%s-operator-metrics", m.asset.Values().Name)
+}
+
+// MetricsSecretName is the name of the Secret the service-ca operator issues
+// the operator metrics serving cert in. The operator Deployment mounts it.
+func (m *monitoring) MetricsSecretName() string {
+	return fmt.Sprintf("%s-operator-metrics-tls", m.asset.Values().Name)
+}
+
+func (m *monitoring) labels() map[string]string {
+	values := m.asset.Values()
+
+	return map[string]string{
+		values.OwnerLabelKey: values.OwnerLabelValue,
+	}
+}
+
+// MetricsService returns the Service in front of the operator metrics endpoint.
+func (m *monitoring) MetricsService() *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.MetricsServiceName(),
+			Namespace: m.asset.Values().Namespace,
+			Labels:    m.labels(),
+			Annotations: map[string]string{
+				ServingCertSecretAnnotationName: m.MetricsSecretName(),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				OperatorSelectorLabelKey: OperatorSelectorLabelValue,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "https",
+					Port:       OperatorMetricsPort,
+					TargetPort: intstr.FromInt(OperatorMetricsPort),
+				},
+			},
+		},
+	}
+}
+
+// PrometheusRole returns the Role that lets Prometheus discover the scrape
+// targets in the operator namespace.
+func (m *monitoring) PrometheusRole() *rbacv1.Role {
+	return &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prometheus-k8s",
+			Namespace: m.asset.Values().Namespace,
+			Labels:    m.labels(),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"services", "endpoints", "pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+}
+
+func (m *monitoring) PrometheusRoleBinding() *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prometheus-k8s",
+			Namespace: m.asset.Values().Namespace,
+			Labels:    m.labels(),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "prometheus-k8s",
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      PrometheusServiceAccountName,
+				Namespace: PrometheusNamespace,
+			},
+		},
+	}
+}
+
+// ServiceMonitor returns the monitoring.coreos.com/v1 ServiceMonitor that has
+// cluster monitoring scrape the operator metrics Service. It is unstructured
+// since the monitoring API is not vendored.
+func (m *monitoring) ServiceMonitor() *unstructured.Unstructured {
+	values := m.asset.Values()
+
+	labels := map[string]interface{}{}
+	for k, v := range m.labels() {
+		labels[k] = v
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       "ServiceMonitor",
+			"metadata": map[string]interface{}{
+				"name":      m.MetricsServiceName(),
+				"namespace": values.Namespace,
+				"labels":    labels,
+			},
+			"spec": map[string]interface{}{
+				"endpoints": []interface{}{
+					map[string]interface{}{
+						"port":            "https",
+						"path":            "/metrics",
+						"scheme":          "https",
+						"interval":        "30s",
+						"bearerTokenFile": "/var/run/secrets/kubernetes.io/serviceaccount/token",
+						"tlsConfig": map[string]interface{}{
+							"caFile":     "/etc/prometheus/configmaps/serving-certs-ca-bundle/service-ca.crt",
+							"serverName": fmt.Sprintf("%s.%s.svc", m.MetricsServiceName(), values.Namespace),
+						},
+					},
+				},
+				"namespaceSelector": map[string]interface{}{
+					"matchNames": []interface{}{values.Namespace},
+				},
+				"selector": map[string]interface{}{
+					"matchLabels": labels,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,150 @@
+package asset
+
+import (
+	"fmt"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+)
+
+const (
+	// RunbookBaseURL is where the runbooks of the alerts are published.
+	RunbookBaseURL = "https://github.com/openshift/run-once-duration-override-operator/blob/master/docs/runbooks"
+
+	defaultWebhookTimeoutSeconds = 5
+)
+
+func (a *Asset) PrometheusRule() *prometheusRule {
+	return &prometheusRule{
+		asset:                 a,
+		workload:              appsv1.WorkloadDaemonSet,
+		failurePolicy:         admissionregistrationv1.Fail,
+		timeoutSeconds:        defaultWebhookTimeoutSeconds,
+		certRotationThreshold: appsv1.DefaultCertRotationThreshold,
+	}
+}
+
+type prometheusRule struct {
+	asset                 *Asset
+	workload              appsv1.WorkloadType
+	failurePolicy         admissionregistrationv1.FailurePolicyType
+	timeoutSeconds        int32
+	certRotationThreshold time.Duration
+}
+
+// WithWorkload sets the workload running the webhook server.
+func (p *prometheusRule) WithWorkload(workload appsv1.WorkloadType) *prometheusRule {
+	p.workload = workload
+	return p
+}
+
+// WithWebhook sets the failure policy and timeout of the webhook. Failed webhook
+// calls are critical with the Fail policy since they block pod creation, and
+// the latency alert fires at half the timeout. Zero values keep the defaults.
+func (p *prometheusRule) WithWebhook(failurePolicy admissionregistrationv1.FailurePolicyType, timeoutSeconds int32) *prometheusRule {
+	if failurePolicy != "" {
+		p.failurePolicy = failurePolicy
+	}
+	if timeoutSeconds != 0 {
+		p.timeoutSeconds = timeoutSeconds
+	}
+	return p
+}
+
+// WithCertRotationThreshold sets how long before expiry the serving cert is
+// rotated. The expiry alert fires when the cert is not rotated in time.
+func (p *prometheusRule) WithCertRotationThreshold(threshold time.Duration) *prometheusRule {
+	p.certRotationThreshold = threshold
+	return p
+}
+
+func (p *prometheusRule) Name() string {
+	return fmt.Sprintf("%s-operator", p.asset.Values().Name)
+}
+
+// New returns the monitoring.coreos.com/v1 PrometheusRule with the alerts on
+// the webhook server.
+func (p *prometheusRule) New() *unstructured.Unstructured {
+	values := p.asset.Values()
+	webhook := p.asset.NewMutatingWebhookConfiguration().Name()
+
+	unavailableExpr := fmt.Sprintf(`kube_daemonset_status_number_unavailable{namespace=%q,daemonset=%q} > 0`, values.Namespace, p.asset.DaemonSet().Name())
+	if p.workload == appsv1.WorkloadDeployment {
+		unavailableExpr = fmt.Sprintf(`kube_deployment_status_replicas_unavailable{namespace=%q,deployment=%q} > 0`, values.Namespace, p.asset.Deployment().Name())
+	}
+
+	errorsSeverity := "warning"
+	if p.failurePolicy == admissionregistrationv1.Fail {
+		errorsSeverity = "critical"
+	}
+
+	rules := []interface{}{
+		alert("RunOnceDurationOverrideWebhookPodsUnavailable",
+			unavailableExpr,
+			"15m", "warning",
+			"Pods of the run-once-duration-override webhook server are unavailable.",
+			fmt.Sprintf("{{ $value }} pods of the %s %s/%s have been unavailable for 15 minutes.", p.workload, values.Namespace, values.Name),
+		),
+		alert("RunOnceDurationOverrideWebhookErrors",
+			fmt.Sprintf(`sum(rate(apiserver_admission_webhook_rejection_count{name=%q,error_type=~"calling_webhook_error|apiserver_internal_error"}[5m])) > 0`, webhook),
+			"10m", errorsSeverity,
+			"The API server fails to call the run-once-duration-override webhook.",
+			fmt.Sprintf("Calls to the %s webhook have been failing for 10 minutes. With the %s failure policy, run-once pods in opted-in namespaces are affected.", webhook, p.failurePolicy),
+		),
+		alert("RunOnceDurationOverrideWebhookLatencyHigh",
+			fmt.Sprintf(`histogram_quantile(0.99, sum by (le) (rate(apiserver_admission_webhook_admission_duration_seconds_bucket{name=%q}[5m]))) > %g`, webhook, float64(p.timeoutSeconds)/2),
+			"15m", "warning",
+			"The run-once-duration-override webhook is slow to respond.",
+			fmt.Sprintf("The 99th percentile latency of the %s webhook is {{ $value | humanizeDuration }}, the webhook times out after %ds.", webhook, p.timeoutSeconds),
+		),
+		alert("RunOnceDurationOverrideServingCertExpiring",
+			fmt.Sprintf(`runoncedurationoverride_serving_cert_expiry_seconds < %g`, p.certRotationThreshold.Seconds()),
+			"1h", "warning",
+			"The serving cert of the run-once-duration-override webhook was not rotated.",
+			fmt.Sprintf("The serving cert expires in {{ $value | humanizeDuration }}, and should have been rotated %s before expiry.", p.certRotationThreshold),
+		),
+	}
+
+	labels := map[string]interface{}{
+		values.OwnerLabelKey: values.OwnerLabelValue,
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       "PrometheusRule",
+			"metadata": map[string]interface{}{
+				"name":      p.Name(),
+				"namespace": values.Namespace,
+				"labels":    labels,
+			},
+			"spec": map[string]interface{}{
+				"groups": []interface{}{
+					map[string]interface{}{
+						"name":  "run-once-duration-override",
+						"rules": rules,
+					},
+				},
+			},
+		},
+	}
+}
+
+func alert(name, expr, duration, severity, summary, description string) map[string]interface{} {
+	return map[string]interface{}{
+		"alert": name,
+		"expr":  expr,
+		"for":   duration,
+		"labels": map[string]interface{}{
+			"severity": severity,
+		},
+		"annotations": map[string]interface{}{
+			"summary":     summary,
+			"description": description,
+			"runbook_url": fmt.Sprintf("%s/%s.md", RunbookBaseURL, name),
+		},
+	}
+}
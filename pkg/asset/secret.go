@@ -42,3 +42,17 @@ func (s *serviceServingSecret) New() *corev1.Secret {
 		},
 	}
 }
+
+// StagedName is the name of the Secret that holds the next serving cert and
+// its CA during a staged rotation.
+func (s *serviceServingSecret) StagedName() string {
+	return fmt.Sprintf("%s-next", s.Name())
+}
+
+func (s *serviceServingSecret) NewStaged() *corev1.Secret {
+	secret := s.New()
+	secret.Name = s.StagedName()
+	secret.Type = corev1.SecretTypeOpaque
+	secret.Data["ca.crt"] = nil
+	return secret
+}
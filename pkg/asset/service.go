@@ -1,6 +1,8 @@
 package asset
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -24,6 +26,16 @@ func (s *service) Name() string {
 	return s.asset.Values().Name
 }
 
+// Hosts returns the DNS names the Service is reachable at from within the cluster.
+func (s *service) Hosts() []string {
+	values := s.asset.Values()
+
+	return []string{
+		fmt.Sprintf("%s.%s.svc", values.Name, values.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", values.Name, values.Namespace),
+	}
+}
+
 func (s *service) New() *corev1.Service {
 	values := s.asset.Values()
 
@@ -43,11 +55,10 @@ func (s *service) New() *corev1.Service {
 			},
 		},
 		Spec: corev1.ServiceSpec{
-			Selector: map[string]string{
-				values.SelectorLabelKey: values.SelectorLabelValue,
-			},
+			Selector: s.asset.Deployment().Labels(),
 			Ports: []corev1.ServicePort{
 				{
+					Name:       "https",
 					Port:       443,
 					TargetPort: intstr.FromInt(8443),
 				},
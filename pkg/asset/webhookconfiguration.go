@@ -18,7 +18,75 @@ func (a *Asset) NewMutatingWebhookConfiguration() *mutatingWebhookConfiguration
 }
 
 type mutatingWebhookConfiguration struct {
-	values *Values
+	values            *Values
+	namespaceSelector *metav1.LabelSelector
+	objectSelector    *metav1.LabelSelector
+	serviceReference  bool
+}
+
+// WithSelectors overrides the default namespace selector and sets an object
+// selector on the webhook. A nil selector keeps the default.
+// Namespaces with openshift.io/run-level 0 or 1 stay excluded regardless
+// of the namespace selector.
+func (m *mutatingWebhookConfiguration) WithSelectors(namespaceSelector, objectSelector *metav1.LabelSelector) *mutatingWebhookConfiguration {
+	m.namespaceSelector = namespaceSelector
+	m.objectSelector = objectSelector
+	return m
+}
+
+// WithServiceReference makes the API server reach the webhook through the
+// Service in front of the Deployment workload instead of https://localhost:9448.
+func (m *mutatingWebhookConfiguration) WithServiceReference() *mutatingWebhookConfiguration {
+	m.serviceReference = true
+	return m
+}
+
+// ClientConfig returns how the API server reaches the webhook server.
+func (m *mutatingWebhookConfiguration) ClientConfig() admissionregistrationv1.WebhookClientConfig {
+	path := fmt.Sprintf("/apis/%s/%s/%s", m.values.AdmissionAPIGroup, m.values.AdmissionAPIVersion, m.values.AdmissionAPIResource)
+
+	if m.serviceReference {
+		port := int32(443)
+		return admissionregistrationv1.WebhookClientConfig{
+			// CABundle will be injected at runtime
+			CABundle: nil,
+			Service: &admissionregistrationv1.ServiceReference{
+				Namespace: m.values.Namespace,
+				Name:      m.values.Name,
+				Path:      &path,
+				Port:      &port,
+			},
+		}
+	}
+
+	url := fmt.Sprintf("https://localhost:9448%s", path)
+	return admissionregistrationv1.WebhookClientConfig{
+		// CABundle will be injected at runtime
+		CABundle: nil,
+		URL:      &url,
+	}
+}
+
+func (m *mutatingWebhookConfiguration) NamespaceSelector() *metav1.LabelSelector {
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			fmt.Sprintf("%s.%s/enabled", m.values.AdmissionAPIResource, m.values.AdmissionAPIGroup): "true",
+		},
+	}
+	if m.namespaceSelector != nil {
+		selector = m.namespaceSelector.DeepCopy()
+	}
+
+	selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      "openshift.io/run-level",
+		Operator: metav1.LabelSelectorOpNotIn,
+		Values: []string{
+			"0",
+			"1",
+		},
+	})
+
+	return selector
 }
 
 func (m *mutatingWebhookConfiguration) Name() string {
@@ -26,10 +94,8 @@ func (m *mutatingWebhookConfiguration) Name() string {
 }
 
 func (m *mutatingWebhookConfiguration) New() *admissionregistrationv1.MutatingWebhookConfiguration {
-	url := fmt.Sprintf("https://localhost:9448/apis/%s/%s/%s", m.values.AdmissionAPIGroup, m.values.AdmissionAPIVersion, m.values.AdmissionAPIResource)
 	policy := admissionregistrationv1.Fail
 	matchPolicy := admissionregistrationv1.Equivalent
-	namespaceMatchLabelKey := fmt.Sprintf("%s.%s/enabled", m.values.AdmissionAPIResource, m.values.AdmissionAPIGroup)
 	timeoutSeconds := int32(5)
 	sideEffects := admissionregistrationv1.SideEffectClassNone
 	reinvoke := admissionregistrationv1.IfNeededReinvocationPolicy
@@ -46,28 +112,11 @@ func (m *mutatingWebhookConfiguration) New() *admissionregistrationv1.MutatingWe
 		},
 		Webhooks: []admissionregistrationv1.MutatingWebhook{
 			{
-				Name: m.Name(),
-				NamespaceSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						namespaceMatchLabelKey: "true",
-					},
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Key:      "openshift.io/run-level",
-							Operator: metav1.LabelSelectorOpNotIn,
-							Values: []string{
-								"0",
-								"1",
-							},
-						},
-					},
-				},
-				MatchPolicy: &matchPolicy,
-				ClientConfig: admissionregistrationv1.WebhookClientConfig{
-					// CABundle will be injected at runtime
-					CABundle: nil,
-					URL:      &url,
-				},
+				Name:              m.Name(),
+				NamespaceSelector: m.NamespaceSelector(),
+				ObjectSelector:    m.objectSelector.DeepCopy(),
+				MatchPolicy:       &matchPolicy,
+				ClientConfig:      m.ClientConfig(),
 				Rules: []admissionregistrationv1.RuleWithOperations{
 					{
 						Operations: []admissionregistrationv1.OperationType{
@@ -68,3 +68,20 @@ func (b *Bundle) Hash() string {
 
 	return hex.EncodeToString(writer.Sum(nil))
 }
+
+// ServingHash generates a sha256 hash of the serving key and cert only, so that
+// changes to the trusted CA bundle alone do not show up as a new serving cert.
+func (b *Bundle) ServingHash() string {
+	writer := sha256.New()
+
+	_, err := writer.Write(b.ServiceKey)
+	if err != nil {
+		return ""
+	}
+	_, err = writer.Write(b.ServiceCert)
+	if err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(writer.Sum(nil))
+}
@@ -4,21 +4,26 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
 )
 
-// GenerateWithLocalhostServing generates self-signed 'localhost' serving cert(s).
+// GenerateWithLocalhostServing generates self-signed 'localhost' serving cert(s)
+// with ECDSA P-256 keys.
 func GenerateWithLocalhostServing(notAfter time.Time, organization string) (bundle *Bundle, err error) {
-	ca, err := GenerateCA(notAfter, organization)
+	return GenerateWithServing(notAfter, organization, []string{"localhost"}, appsv1.KeyAlgorithmECDSAP256)
+}
+
+// GenerateWithServing generates a self-signed CA and a serving cert for the given
+// hosts, with keys of the given algorithm.
+func GenerateWithServing(notAfter time.Time, organization string, hosts []string, algorithm appsv1.KeyAlgorithm) (bundle *Bundle, err error) {
+	ca, err := GenerateCA(notAfter, organization, algorithm)
 	if err != nil {
 		return
 	}
 
 	// Create signed serving cert
-	hosts := []string{
-		"localhost",
-	}
-
-	servingPair, err := CreateSignedServingPair(notAfter, organization, ca, hosts)
+	servingPair, err := CreateSignedServingPair(notAfter, organization, ca, hosts, algorithm)
 	if err != nil {
 		return
 	}
@@ -61,3 +66,24 @@ func IsPopulated(secret *corev1.Secret) bool {
 
 	return true
 }
+
+// CoversHosts returns true if the serving cert in the given Secret object is
+// valid for all of the given hosts.
+func CoversHosts(secret *corev1.Secret, hosts []string) bool {
+	if !IsPopulated(secret) {
+		return false
+	}
+
+	cert, err := PEMToCert(secret.Data["tls.crt"])
+	if err != nil {
+		return false
+	}
+
+	for _, host := range hosts {
+		if err := cert.VerifyHostname(host); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,35 +1,47 @@
 package cert
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
 	"math"
 	"math/big"
+	"strings"
 	"time"
+
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
 )
 
-// KeyPair stores an x509 certificate and its ECDSA private key
+// KeyPair stores an x509 certificate and its ECDSA or RSA private key
 type KeyPair struct {
 	Cert *x509.Certificate
-	Priv *ecdsa.PrivateKey
+	Priv crypto.Signer
 }
 
 // ToPEM returns the PEM encoded cert pair
 func (kp *KeyPair) ToPEM() (certPEM []byte, privPEM []byte, err error) {
 	// PEM encode private key
-	privDER, err := x509.MarshalECPrivateKey(kp.Priv)
-	if err != nil {
+	privBlock := &pem.Block{}
+	switch priv := kp.Priv.(type) {
+	case *ecdsa.PrivateKey:
+		privBlock.Type = "EC PRIVATE KEY"
+		privBlock.Bytes, err = x509.MarshalECPrivateKey(priv)
+		if err != nil {
+			return
+		}
+	case *rsa.PrivateKey:
+		privBlock.Type = "RSA PRIVATE KEY"
+		privBlock.Bytes = x509.MarshalPKCS1PrivateKey(priv)
+	default:
+		err = fmt.Errorf("unsupported private key type %T", kp.Priv)
 		return
 	}
-	privBlock := &pem.Block{
-		Type:  "EC PRIVATE KEY",
-		Bytes: privDER,
-	}
 	privPEM = pem.EncodeToMemory(privBlock)
 
 	// PEM encode cert
@@ -42,8 +54,37 @@ func (kp *KeyPair) ToPEM() (certPEM []byte, privPEM []byte, err error) {
 	return
 }
 
-// GenerateCA generates a self-signed CA cert/key pair that expires in expiresIn days
-func GenerateCA(notAfter time.Time, organization string) (*KeyPair, error) {
+// GenerateKey generates a private key with the given algorithm, an empty
+// algorithm defaults to ECDSA P-256.
+func GenerateKey(algorithm appsv1.KeyAlgorithm) (crypto.Signer, error) {
+	switch algorithm {
+	case "", appsv1.KeyAlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case appsv1.KeyAlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case appsv1.KeyAlgorithmRSA3072:
+		return rsa.GenerateKey(rand.Reader, 3072)
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %q", algorithm)
+	}
+}
+
+// KeyAlgorithmOf returns the algorithm and size of the public key of the given
+// cert, for example ECDSA-P256 or RSA-2048, or the public key algorithm alone
+// for other key types.
+func KeyAlgorithmOf(cert *x509.Certificate) appsv1.KeyAlgorithm {
+	switch key := cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		return appsv1.KeyAlgorithm("ECDSA-" + strings.ReplaceAll(key.Curve.Params().Name, "-", ""))
+	case *rsa.PublicKey:
+		return appsv1.KeyAlgorithm(fmt.Sprintf("RSA-%d", key.N.BitLen()))
+	}
+
+	return appsv1.KeyAlgorithm(cert.PublicKeyAlgorithm.String())
+}
+
+// GenerateCA generates a self-signed CA cert/key pair that expires at notAfter
+func GenerateCA(notAfter time.Time, organization string, algorithm appsv1.KeyAlgorithm) (*KeyPair, error) {
 	notBefore := time.Now()
 	if notAfter.Before(notBefore) {
 		return nil, fmt.Errorf("invalid notAfter: %s before %s", notAfter.String(), notBefore.String())
@@ -67,12 +108,12 @@ func GenerateCA(notAfter time.Time, organization string) (*KeyPair, error) {
 		BasicConstraintsValid: true,
 	}
 
-	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privateKey, err := GenerateKey(algorithm)
 	if err != nil {
 		return nil, err
 	}
 
-	publicKey := &privateKey.PublicKey
+	publicKey := privateKey.Public()
 	certRaw, err := x509.CreateCertificate(rand.Reader, caDetails, caDetails, publicKey, privateKey)
 	if err != nil {
 		return nil, err
@@ -92,7 +133,7 @@ func GenerateCA(notAfter time.Time, organization string) (*KeyPair, error) {
 }
 
 // CreateSignedServingPair creates a serving cert/key pair signed by the given ca
-func CreateSignedServingPair(notAfter time.Time, organization string, ca *KeyPair, hosts []string) (*KeyPair, error) {
+func CreateSignedServingPair(notAfter time.Time, organization string, ca *KeyPair, hosts []string, algorithm appsv1.KeyAlgorithm) (*KeyPair, error) {
 	notBefore := time.Now()
 	if notAfter.Before(notBefore) {
 		return nil, fmt.Errorf("invalid notAfter: %s before %s", notAfter.String(), notBefore.String())
@@ -116,12 +157,17 @@ func CreateSignedServingPair(notAfter time.Time, organization string, ca *KeyPai
 		DNSNames:              hosts,
 	}
 
-	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privateKey, err := GenerateKey(algorithm)
 	if err != nil {
 		return nil, err
 	}
 
-	publicKey := &privateKey.PublicKey
+	if _, ok := privateKey.(*rsa.PrivateKey); ok {
+		// RSA key exchange encrypts the session key with the public key.
+		certDetails.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	publicKey := privateKey.Public()
 	certRaw, err := x509.CreateCertificate(rand.Reader, certDetails, ca.Cert, publicKey, ca.Priv)
 	if err != nil {
 		return nil, err
@@ -155,6 +201,37 @@ func PEMToCert(certPEM []byte) (*x509.Certificate, error) {
 	return cert, nil
 }
 
+// PEMToCerts converts all of the PEM blocks of the given byte array to x509 certificates
+func PEMToCerts(certPEM []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(certPEM); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("cert PEM empty")
+	}
+
+	return certs, nil
+}
+
+// IssuerOf returns the CA of the given bundle that signed the given cert, or nil
+// if none of them did.
+func IssuerOf(cert *x509.Certificate, cas []*x509.Certificate) *x509.Certificate {
+	for _, ca := range cas {
+		if cert.CheckSignatureFrom(ca) == nil {
+			return ca
+		}
+	}
+
+	return nil
+}
+
 // VerifyCert checks that the given cert is signed and trusted by the given CA
 func VerifyCert(ca, cert *x509.Certificate, host string) error {
 	roots := x509.NewCertPool()
@@ -0,0 +1,43 @@
+package cert
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+)
+
+func TestGenerateWithServingKeyAlgorithm(t *testing.T) {
+	for _, algorithm := range []appsv1.KeyAlgorithm{appsv1.KeyAlgorithmECDSAP256, appsv1.KeyAlgorithmECDSAP384, appsv1.KeyAlgorithmRSA3072} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			bundle, err := GenerateWithServing(time.Now().Add(time.Hour), "test", []string{"localhost"}, algorithm)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if _, err := tls.X509KeyPair(bundle.ServiceCert, bundle.ServiceKey); err != nil {
+				t.Errorf("expected a usable serving key pair: %v", err)
+			}
+
+			ca, err := PEMToCert(bundle.ServingCertCA)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			serving, err := PEMToCert(bundle.ServiceCert)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := VerifyCert(ca, serving, "localhost"); err != nil {
+				t.Errorf("expected the serving cert to be signed by the CA: %v", err)
+			}
+
+			if got := KeyAlgorithmOf(ca); got != algorithm {
+				t.Errorf("expected CA key algorithm %s, got %s", algorithm, got)
+			}
+			if got := KeyAlgorithmOf(serving); got != algorithm {
+				t.Errorf("expected serving key algorithm %s, got %s", algorithm, got)
+			}
+		})
+	}
+}
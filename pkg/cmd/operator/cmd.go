@@ -1,20 +1,65 @@
 package operator
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 	"k8s.io/utils/clock"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/library-go/pkg/config/leaderelection"
 	"github.com/openshift/library-go/pkg/controller/controllercmd"
 	"github.com/openshift/run-once-duration-override-operator/pkg/operator"
 	"github.com/openshift/run-once-duration-override-operator/pkg/version"
 )
 
 func NewStartCommand() *cobra.Command {
-	cmd := controllercmd.
-		NewControllerCommandConfig("runoncedurationoverride", version.Get(), operator.RunOperator, clock.RealClock{}).
-		NewCommand()
+	config := controllercmd.NewControllerCommandConfig("runoncedurationoverride", version.Get(), operator.RunOperator, clock.RealClock{})
+
+	cmd := config.NewCommand()
 	cmd.Use = "start"
 	cmd.Short = "Start the RunOnceDurationOverride Operator"
 
+	flags := cmd.Flags()
+	// Zero values keep the library-go defaults (137s, 107s and 26s), which
+	// tolerate 78s of kube-apiserver downtime without losing the lease.
+	flags.DurationVar(&config.LeaseDuration.Duration, "leader-election-lease-duration", 0, "The duration that non-leader replicas wait before forcing acquisition of the leader lease.")
+	flags.DurationVar(&config.RenewDeadline.Duration, "leader-election-renew-deadline", 0, "The duration that the leader retries renewing the leader lease before giving it up.")
+	flags.DurationVar(&config.RetryPeriod.Duration, "leader-election-retry-period", 0, "The duration replicas wait between tries to acquire or renew the leader lease.")
+	flags.BoolVar(&config.DisableLeaderElection, "disable-leader-election", false, "Run without leader election. Only use with a single replica.")
+
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return validateLeaderElection(config)
+	}
+
+	// The health check is served before leader election, so that replicas
+	// waiting for the lease are live and ready.
+	run := cmd.Run
+	cmd.Run = func(cmd *cobra.Command, args []string) {
+		go operator.ServeHealthz(operator.HealthzBindAddress)
+		run(cmd, args)
+	}
+
 	return cmd
 }
+
+// validateLeaderElection checks the lease timings after defaulting, the leader
+// elector would otherwise panic on start.
+func validateLeaderElection(config *controllercmd.ControllerCommandConfig) error {
+	defaulted := leaderelection.LeaderElectionDefaulting(configv1.LeaderElection{
+		LeaseDuration: config.LeaseDuration,
+		RenewDeadline: config.RenewDeadline,
+		RetryPeriod:   config.RetryPeriod,
+	}, "", "")
+
+	lease, renew, retry := defaulted.LeaseDuration.Duration, defaulted.RenewDeadline.Duration, defaulted.RetryPeriod.Duration
+	switch {
+	case lease <= renew:
+		return fmt.Errorf("--leader-election-lease-duration (%s) must be greater than --leader-election-renew-deadline (%s)", lease, renew)
+	case renew <= time.Duration(1.2*float64(retry)):
+		return fmt.Errorf("--leader-election-renew-deadline (%s) must be greater than 1.2 times --leader-election-retry-period (%s)", renew, retry)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package operator
+
+import (
+	"testing"
+)
+
+func TestStartCommandLeaderElection(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name: "Defaults",
+		},
+		{
+			name: "ShorterLease",
+			args: []string{"--leader-election-lease-duration=60s", "--leader-election-renew-deadline=40s", "--leader-election-retry-period=10s"},
+		},
+		{
+			name:    "LeaseNotLongerThanRenewDeadline",
+			args:    []string{"--leader-election-lease-duration=60s"},
+			wantErr: true,
+		},
+		{
+			name:    "RetryPeriodTooLong",
+			args:    []string{"--leader-election-retry-period=100s"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewStartCommand()
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err := cmd.PreRunE(cmd, nil)
+			if tt.wantErr != (err != nil) {
+				t.Errorf("expected error=%t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -11,8 +11,8 @@ import (
 
 	operatorsv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/library-go/pkg/operator/events"
-	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
 	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
 	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
 	operatorruntime "github.com/openshift/run-once-duration-override-operator/pkg/runtime"
 )
@@ -71,7 +71,7 @@ func (d *daemonset) Ensure(parent, child Applier, generations []operatorsv1.Gene
 		child.Apply(&desired.Spec.Template)
 	}
 
-	current, _, err = resourceapply.ApplyDaemonSet(gocontext.TODO(), d.client.AppsV1(), d.recorder, desired, resourcemerge.ExpectedDaemonSetGeneration(desired, generations))
+	current, _, err = apply.DaemonSet(gocontext.TODO(), d.client.AppsV1(), d.recorder, desired, resourcemerge.ExpectedDaemonSetGeneration(desired, generations))
 	if err != nil {
 		return
 	}
@@ -0,0 +1,81 @@
+package deploy
+
+import (
+	gocontext "context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	listersappsv1 "k8s.io/client-go/listers/apps/v1"
+
+	operatorsv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+	operatorruntime "github.com/openshift/run-once-duration-override-operator/pkg/runtime"
+)
+
+func NewDeploymentInstall(lister listersappsv1.DeploymentLister, oc operatorruntime.OperandContext, asset *asset.Asset, client kubernetes.Interface, recorder events.Recorder) Interface {
+	return &deployment{
+		lister:   lister,
+		context:  oc,
+		asset:    asset,
+		client:   client,
+		recorder: recorder,
+	}
+}
+
+type deployment struct {
+	lister   listersappsv1.DeploymentLister
+	context  operatorruntime.OperandContext
+	asset    *asset.Asset
+	client   kubernetes.Interface
+	recorder events.Recorder
+}
+
+func (d *deployment) Name() string {
+	return d.asset.Deployment().Name()
+}
+
+func (d *deployment) IsAvailable() (available bool, err error) {
+	name := d.asset.Deployment().Name()
+	current, err := d.lister.Deployments(d.context.WebhookNamespace()).Get(name)
+	if err != nil {
+		return
+	}
+
+	available, err = GetDeploymentStatus(current)
+	return
+}
+
+func (d *deployment) Get() (object runtime.Object, accessor metav1.Object, err error) {
+	name := d.asset.Deployment().Name()
+	object, err = d.lister.Deployments(d.context.WebhookNamespace()).Get(name)
+	if err != nil {
+		return
+	}
+
+	accessor, err = meta.Accessor(object)
+	return
+}
+
+func (d *deployment) Ensure(parent, child Applier, generations []operatorsv1.GenerationStatus) (current runtime.Object, accessor metav1.Object, err error) {
+	desired := d.asset.Deployment().New()
+
+	if parent != nil {
+		parent.Apply(desired)
+	}
+	if child != nil {
+		child.Apply(&desired.Spec.Template)
+	}
+
+	current, _, err = apply.Deployment(gocontext.TODO(), d.client.AppsV1(), d.recorder, desired, resourcemerge.ExpectedDeploymentGeneration(desired, generations))
+	if err != nil {
+		return
+	}
+
+	accessor, err = meta.Accessor(current)
+	return
+}
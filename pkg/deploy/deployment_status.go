@@ -0,0 +1,38 @@
+package deploy
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func GetDeploymentStatus(d *appsv1.Deployment) (ready bool, err error) {
+	if d.Generation > d.Status.ObservedGeneration {
+		err = fmt.Errorf("waiting for deployment spec update name=%s", d.Name)
+		return
+	}
+
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	if d.Status.UpdatedReplicas < replicas {
+		err = fmt.Errorf("waiting for deployment pods to be updated name=%s", d.Name)
+		return
+	}
+
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		err = fmt.Errorf("waiting for old deployment pods to be terminated name=%s", d.Name)
+		return
+	}
+
+	if d.Status.AvailableReplicas < replicas {
+		err = fmt.Errorf("waiting for deployment pods to be available name=%s", d.Name)
+		return
+	}
+
+	// Deployment is finished
+	ready = true
+	return
+}
@@ -0,0 +1,55 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertificateConfigApplyConfiguration represents a declarative configuration of the CertificateConfig type for use
+// with apply.
+//
+// CertificateConfig holds the settings of the self-signed serving certs.
+// Changes are picked up by a staged rotation of the current certs.
+type CertificateConfigApplyConfiguration struct {
+	// Validity is how long the generated CA and serving cert are valid for.
+	// It must be at least 24h. Defaults to 8760h (365 days).
+	Validity *metav1.Duration `json:"validity,omitempty"`
+	// RotationThreshold is how long before expiry the certs are rotated.
+	// It must be shorter than Validity. Defaults to 48h.
+	RotationThreshold *metav1.Duration `json:"rotationThreshold,omitempty"`
+	// KeyAlgorithm is the algorithm and size of the generated private keys.
+	// Defaults to ECDSA-P256.
+	KeyAlgorithm *runoncedurationoverridev1.KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+}
+
+// CertificateConfigApplyConfiguration constructs a declarative configuration of the CertificateConfig type for use with
+// apply.
+func CertificateConfig() *CertificateConfigApplyConfiguration {
+	return &CertificateConfigApplyConfiguration{}
+}
+
+// WithValidity sets the Validity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Validity field is set to the value of the last call.
+func (b *CertificateConfigApplyConfiguration) WithValidity(value metav1.Duration) *CertificateConfigApplyConfiguration {
+	b.Validity = &value
+	return b
+}
+
+// WithRotationThreshold sets the RotationThreshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RotationThreshold field is set to the value of the last call.
+func (b *CertificateConfigApplyConfiguration) WithRotationThreshold(value metav1.Duration) *CertificateConfigApplyConfiguration {
+	b.RotationThreshold = &value
+	return b
+}
+
+// WithKeyAlgorithm sets the KeyAlgorithm field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KeyAlgorithm field is set to the value of the last call.
+func (b *CertificateConfigApplyConfiguration) WithKeyAlgorithm(value runoncedurationoverridev1.KeyAlgorithm) *CertificateConfigApplyConfiguration {
+	b.KeyAlgorithm = &value
+	return b
+}
@@ -0,0 +1,50 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertificatesStatusApplyConfiguration represents a declarative configuration of the CertificatesStatus type for use
+// with apply.
+//
+// CertificatesStatus describes the serving cert in use and the CA that signed it.
+type CertificatesStatusApplyConfiguration struct {
+	// CA describes the CA that signed the serving cert.
+	CA *CertificateStatusApplyConfiguration `json:"ca,omitempty"`
+	// Serving describes the serving cert of the webhook server.
+	Serving *CertificateStatusApplyConfiguration `json:"serving,omitempty"`
+	// LastRotationTime is the time the operator last observed a new serving cert.
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+}
+
+// CertificatesStatusApplyConfiguration constructs a declarative configuration of the CertificatesStatus type for use with
+// apply.
+func CertificatesStatus() *CertificatesStatusApplyConfiguration {
+	return &CertificatesStatusApplyConfiguration{}
+}
+
+// WithCA sets the CA field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CA field is set to the value of the last call.
+func (b *CertificatesStatusApplyConfiguration) WithCA(value *CertificateStatusApplyConfiguration) *CertificatesStatusApplyConfiguration {
+	b.CA = value
+	return b
+}
+
+// WithServing sets the Serving field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Serving field is set to the value of the last call.
+func (b *CertificatesStatusApplyConfiguration) WithServing(value *CertificateStatusApplyConfiguration) *CertificatesStatusApplyConfiguration {
+	b.Serving = value
+	return b
+}
+
+// WithLastRotationTime sets the LastRotationTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastRotationTime field is set to the value of the last call.
+func (b *CertificatesStatusApplyConfiguration) WithLastRotationTime(value metav1.Time) *CertificatesStatusApplyConfiguration {
+	b.LastRotationTime = &value
+	return b
+}
@@ -0,0 +1,85 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertificateStatusApplyConfiguration represents a declarative configuration of the CertificateStatus type for use
+// with apply.
+//
+// CertificateStatus describes an x509 certificate.
+type CertificateStatusApplyConfiguration struct {
+	// SerialNumber is the hex encoded serial number of the cert.
+	SerialNumber *string `json:"serialNumber,omitempty"`
+	// NotBefore is the start of the validity window of the cert.
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+	// NotAfter is the expiry of the cert.
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// DNSNames are the DNS subject alternative names of the cert.
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// IPAddresses are the IP address subject alternative names of the cert.
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// KeyAlgorithm is the algorithm and size of the public key of the cert,
+	// for example ECDSA-P256 or RSA-3072.
+	KeyAlgorithm *string `json:"keyAlgorithm,omitempty"`
+}
+
+// CertificateStatusApplyConfiguration constructs a declarative configuration of the CertificateStatus type for use with
+// apply.
+func CertificateStatus() *CertificateStatusApplyConfiguration {
+	return &CertificateStatusApplyConfiguration{}
+}
+
+// WithSerialNumber sets the SerialNumber field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SerialNumber field is set to the value of the last call.
+func (b *CertificateStatusApplyConfiguration) WithSerialNumber(value string) *CertificateStatusApplyConfiguration {
+	b.SerialNumber = &value
+	return b
+}
+
+// WithNotBefore sets the NotBefore field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotBefore field is set to the value of the last call.
+func (b *CertificateStatusApplyConfiguration) WithNotBefore(value metav1.Time) *CertificateStatusApplyConfiguration {
+	b.NotBefore = &value
+	return b
+}
+
+// WithNotAfter sets the NotAfter field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotAfter field is set to the value of the last call.
+func (b *CertificateStatusApplyConfiguration) WithNotAfter(value metav1.Time) *CertificateStatusApplyConfiguration {
+	b.NotAfter = &value
+	return b
+}
+
+// WithDNSNames adds the given value to the DNSNames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DNSNames field.
+func (b *CertificateStatusApplyConfiguration) WithDNSNames(values ...string) *CertificateStatusApplyConfiguration {
+	for i := range values {
+		b.DNSNames = append(b.DNSNames, values[i])
+	}
+	return b
+}
+
+// WithIPAddresses adds the given value to the IPAddresses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IPAddresses field.
+func (b *CertificateStatusApplyConfiguration) WithIPAddresses(values ...string) *CertificateStatusApplyConfiguration {
+	for i := range values {
+		b.IPAddresses = append(b.IPAddresses, values[i])
+	}
+	return b
+}
+
+// WithKeyAlgorithm sets the KeyAlgorithm field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KeyAlgorithm field is set to the value of the last call.
+func (b *CertificateStatusApplyConfiguration) WithKeyAlgorithm(value string) *CertificateStatusApplyConfiguration {
+	b.KeyAlgorithm = &value
+	return b
+}
@@ -0,0 +1,56 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertRotationStatusApplyConfiguration represents a declarative configuration of the CertRotationStatus type for use
+// with apply.
+//
+// CertRotationStatus tracks the staged rotation of the self-signed serving certs.
+// The new CA is trusted next to the old one before the serving cert it signed is
+// rolled out, and the old CA is dropped once every pod serves the new cert.
+type CertRotationStatusApplyConfiguration struct {
+	// Phase is the phase of the rotation in progress, empty when there is none.
+	// TrustingNewCA: the CA bundle holds the old and the new CA, pods serve the old cert.
+	// RollingOutServingCert: the CA bundle holds the old and the new CA, the serving
+	// cert signed by the new CA is being rolled out.
+	Phase *runoncedurationoverridev1.CertRotationPhase `json:"phase,omitempty"`
+	// LastTransitionTime is the time the rotation entered the current phase.
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// LastCompletionTime is the time the last staged rotation completed.
+	LastCompletionTime *metav1.Time `json:"lastCompletionTime,omitempty"`
+}
+
+// CertRotationStatusApplyConfiguration constructs a declarative configuration of the CertRotationStatus type for use with
+// apply.
+func CertRotationStatus() *CertRotationStatusApplyConfiguration {
+	return &CertRotationStatusApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *CertRotationStatusApplyConfiguration) WithPhase(value runoncedurationoverridev1.CertRotationPhase) *CertRotationStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithLastTransitionTime sets the LastTransitionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastTransitionTime field is set to the value of the last call.
+func (b *CertRotationStatusApplyConfiguration) WithLastTransitionTime(value metav1.Time) *CertRotationStatusApplyConfiguration {
+	b.LastTransitionTime = &value
+	return b
+}
+
+// WithLastCompletionTime sets the LastCompletionTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastCompletionTime field is set to the value of the last call.
+func (b *CertRotationStatusApplyConfiguration) WithLastCompletionTime(value metav1.Time) *CertRotationStatusApplyConfiguration {
+	b.LastCompletionTime = &value
+	return b
+}
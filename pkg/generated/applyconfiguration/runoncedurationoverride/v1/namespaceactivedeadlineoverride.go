@@ -0,0 +1,43 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// NamespaceActiveDeadlineOverrideApplyConfiguration represents a declarative configuration of the NamespaceActiveDeadlineOverride type for use
+// with apply.
+//
+// NamespaceActiveDeadlineOverride overrides activeDeadlineSeconds for the
+// namespaces selected by NamespaceSelector.
+type NamespaceActiveDeadlineOverrideApplyConfiguration struct {
+	// NamespaceSelector selects the namespaces this rule applies to.
+	// An empty selector matches all namespaces.
+	NamespaceSelector *metav1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	// ActiveDeadlineSeconds (if > 0) overrides activeDeadlineSeconds field of pod
+	// in the selected namespaces.
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// NamespaceActiveDeadlineOverrideApplyConfiguration constructs a declarative configuration of the NamespaceActiveDeadlineOverride type for use with
+// apply.
+func NamespaceActiveDeadlineOverride() *NamespaceActiveDeadlineOverrideApplyConfiguration {
+	return &NamespaceActiveDeadlineOverrideApplyConfiguration{}
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *NamespaceActiveDeadlineOverrideApplyConfiguration) WithNamespaceSelector(value *metav1.LabelSelectorApplyConfiguration) *NamespaceActiveDeadlineOverrideApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithActiveDeadlineSeconds sets the ActiveDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ActiveDeadlineSeconds field is set to the value of the last call.
+func (b *NamespaceActiveDeadlineOverrideApplyConfiguration) WithActiveDeadlineSeconds(value int64) *NamespaceActiveDeadlineOverrideApplyConfiguration {
+	b.ActiveDeadlineSeconds = &value
+	return b
+}
@@ -0,0 +1,74 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// OperandPlacementApplyConfiguration represents a declarative configuration of the OperandPlacement type for use
+// with apply.
+//
+// OperandPlacement holds the scheduling settings of the webhook server pods.
+type OperandPlacementApplyConfiguration struct {
+	// NodeSelector replaces the default node selector of the webhook server pods.
+	// The DaemonSet workload defaults to node-role.kubernetes.io/master, the
+	// Deployment workload to none.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations replaces the default tolerations of the webhook server pods.
+	// The DaemonSet workload defaults to tolerating the node-role.kubernetes.io/master
+	// NoSchedule taint, and unreachable or not-ready nodes for 120 seconds.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Resources are the compute resources of the webhook server container.
+	// Defaults to no requests and limits.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// PriorityClassName is the priority class of the webhook server pods.
+	// Defaults to none.
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+}
+
+// OperandPlacementApplyConfiguration constructs a declarative configuration of the OperandPlacement type for use with
+// apply.
+func OperandPlacement() *OperandPlacementApplyConfiguration {
+	return &OperandPlacementApplyConfiguration{}
+}
+
+// WithNodeSelector puts the entries into the NodeSelector field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the NodeSelector field,
+// overwriting an existing map entries in NodeSelector field with the same key.
+func (b *OperandPlacementApplyConfiguration) WithNodeSelector(entries map[string]string) *OperandPlacementApplyConfiguration {
+	if b.NodeSelector == nil && len(entries) > 0 {
+		b.NodeSelector = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.NodeSelector[k] = v
+	}
+	return b
+}
+
+// WithTolerations adds the given value to the Tolerations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Tolerations field.
+func (b *OperandPlacementApplyConfiguration) WithTolerations(values ...corev1.Toleration) *OperandPlacementApplyConfiguration {
+	for i := range values {
+		b.Tolerations = append(b.Tolerations, values[i])
+	}
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *OperandPlacementApplyConfiguration) WithResources(value corev1.ResourceRequirements) *OperandPlacementApplyConfiguration {
+	b.Resources = &value
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *OperandPlacementApplyConfiguration) WithPriorityClassName(value string) *OperandPlacementApplyConfiguration {
+	b.PriorityClassName = &value
+	return b
+}
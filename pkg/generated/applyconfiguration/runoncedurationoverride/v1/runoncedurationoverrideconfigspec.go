@@ -2,12 +2,30 @@
 
 package v1
 
+import (
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+)
+
 // RunOnceDurationOverrideConfigSpecApplyConfiguration represents a declarative configuration of the RunOnceDurationOverrideConfigSpec type for use
 // with apply.
 type RunOnceDurationOverrideConfigSpecApplyConfiguration struct {
 	// ActiveDeadlineSeconds (if > 0) overrides activeDeadlineSeconds field of pod;
 	// if pod's restartPolicy is set to Never or OnFailure.
+	// It is the default value applied to pods whose namespace does not match
+	// any of the NamespaceOverrides rules.
 	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// Mode controls how the admission webhook enforces the override.
+	// Enforce sets activeDeadlineSeconds on matching pods.
+	// Audit only annotates matching pods and returns a warning, leaving
+	// activeDeadlineSeconds unchanged.
+	// Disabled removes the webhook registration, the webhook server keeps running.
+	// Defaults to Enforce.
+	Mode *runoncedurationoverridev1.OverrideMode `json:"mode,omitempty"`
+	// NamespaceOverrides is an ordered list of rules that set a different
+	// activeDeadlineSeconds for pods in the namespaces selected by the rule.
+	// The first rule whose namespaceSelector matches the labels of the pod's
+	// namespace wins; if no rule matches, ActiveDeadlineSeconds is used.
+	NamespaceOverrides []NamespaceActiveDeadlineOverrideApplyConfiguration `json:"namespaceOverrides,omitempty"`
 }
 
 // RunOnceDurationOverrideConfigSpecApplyConfiguration constructs a declarative configuration of the RunOnceDurationOverrideConfigSpec type for use with
@@ -23,3 +41,24 @@ func (b *RunOnceDurationOverrideConfigSpecApplyConfiguration) WithActiveDeadline
 	b.ActiveDeadlineSeconds = &value
 	return b
 }
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *RunOnceDurationOverrideConfigSpecApplyConfiguration) WithMode(value runoncedurationoverridev1.OverrideMode) *RunOnceDurationOverrideConfigSpecApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithNamespaceOverrides adds the given value to the NamespaceOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NamespaceOverrides field.
+func (b *RunOnceDurationOverrideConfigSpecApplyConfiguration) WithNamespaceOverrides(values ...*NamespaceActiveDeadlineOverrideApplyConfiguration) *RunOnceDurationOverrideConfigSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNamespaceOverrides")
+		}
+		b.NamespaceOverrides = append(b.NamespaceOverrides, *values[i])
+	}
+	return b
+}
@@ -8,6 +8,8 @@ type RunOnceDurationOverrideResourceHashApplyConfiguration struct {
 	Configuration  *string `json:"configuration,omitempty"`
 	ServingCert    *string `json:"servingCert,omitempty"`
 	ObservedConfig *string `json:"observedConfig,omitempty"`
+	// OperandPlacement is the hash of spec.operandPlacement, empty when it is not set.
+	OperandPlacement *string `json:"operandPlacement,omitempty"`
 }
 
 // RunOnceDurationOverrideResourceHashApplyConfiguration constructs a declarative configuration of the RunOnceDurationOverrideResourceHash type for use with
@@ -39,3 +41,11 @@ func (b *RunOnceDurationOverrideResourceHashApplyConfiguration) WithObservedConf
 	b.ObservedConfig = &value
 	return b
 }
+
+// WithOperandPlacement sets the OperandPlacement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OperandPlacement field is set to the value of the last call.
+func (b *RunOnceDurationOverrideResourceHashApplyConfiguration) WithOperandPlacement(value string) *RunOnceDurationOverrideResourceHashApplyConfiguration {
+	b.OperandPlacement = &value
+	return b
+}
@@ -32,6 +32,12 @@ type RunOnceDurationOverrideResourcesApplyConfiguration struct {
 	// APiServiceRef points to the APIService object related to the RunOnceDurationOverride
 	// admission webhook server.
 	MutatingWebhookConfigurationRef *corev1.ObjectReference `json:"mutatingWebhookConfigurationRef,omitempty"`
+	// MutatingAdmissionPolicyRef points to the MutatingAdmissionPolicy object
+	// generated in the AdmissionPolicy deployment mode.
+	MutatingAdmissionPolicyRef *corev1.ObjectReference `json:"mutatingAdmissionPolicyRef,omitempty"`
+	// MutatingAdmissionPolicyBindingRef points to the MutatingAdmissionPolicyBinding
+	// object generated in the AdmissionPolicy deployment mode.
+	MutatingAdmissionPolicyBindingRef *corev1.ObjectReference `json:"mutatingAdmissionPolicyBindingRef,omitempty"`
 }
 
 // RunOnceDurationOverrideResourcesApplyConfiguration constructs a declarative configuration of the RunOnceDurationOverrideResources type for use with
@@ -95,3 +101,19 @@ func (b *RunOnceDurationOverrideResourcesApplyConfiguration) WithMutatingWebhook
 	b.MutatingWebhookConfigurationRef = &value
 	return b
 }
+
+// WithMutatingAdmissionPolicyRef sets the MutatingAdmissionPolicyRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MutatingAdmissionPolicyRef field is set to the value of the last call.
+func (b *RunOnceDurationOverrideResourcesApplyConfiguration) WithMutatingAdmissionPolicyRef(value corev1.ObjectReference) *RunOnceDurationOverrideResourcesApplyConfiguration {
+	b.MutatingAdmissionPolicyRef = &value
+	return b
+}
+
+// WithMutatingAdmissionPolicyBindingRef sets the MutatingAdmissionPolicyBindingRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MutatingAdmissionPolicyBindingRef field is set to the value of the last call.
+func (b *RunOnceDurationOverrideResourcesApplyConfiguration) WithMutatingAdmissionPolicyBindingRef(value corev1.ObjectReference) *RunOnceDurationOverrideResourcesApplyConfiguration {
+	b.MutatingAdmissionPolicyBindingRef = &value
+	return b
+}
@@ -5,6 +5,7 @@ package v1
 import (
 	apioperatorv1 "github.com/openshift/api/operator/v1"
 	operatorv1 "github.com/openshift/client-go/operator/applyconfigurations/operator/v1"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -13,6 +14,41 @@ import (
 type RunOnceDurationOverrideSpecApplyConfiguration struct {
 	operatorv1.OperatorSpecApplyConfiguration `json:",inline"`
 	RunOnceDurationOverrideConfig             *RunOnceDurationOverrideConfigApplyConfiguration `json:"runOnceDurationOverride,omitempty"`
+	// Webhook configures how the admission webhook is registered with the API server.
+	Webhook *WebhookConfigApplyConfiguration `json:"webhook,omitempty"`
+	// DeploymentMode selects how the override is implemented.
+	// Webhook runs the admission webhook server.
+	// AdmissionPolicy generates a MutatingAdmissionPolicy and its binding instead,
+	// and falls back to Webhook when the MutatingAdmissionPolicy API is not served.
+	// Defaults to Webhook.
+	DeploymentMode *runoncedurationoverridev1.DeploymentMode `json:"deploymentMode,omitempty"`
+	// Workload selects how the admission webhook server runs in the Webhook deployment mode.
+	// DaemonSet runs it with hostNetwork on every master node, reached at https://localhost:9448.
+	// Deployment runs a replicated Deployment behind a Service, reached through a service reference,
+	// for clusters where the control plane is hosted elsewhere.
+	// Defaults to DaemonSet.
+	Workload *runoncedurationoverridev1.WorkloadType `json:"workload,omitempty"`
+	// Replicas is the number of webhook server pods of the Deployment workload.
+	// Defaults to 2.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// CertSource selects where the serving cert of the webhook server comes from.
+	// SelfSigned generates a self-signed CA and serving cert, rotated by the operator.
+	// ServiceCA uses the serving cert issued by the OpenShift service-ca operator for the
+	// operand Service and the CA bundle it injects, rotation is then owned by the platform.
+	// ServiceCA requires the Deployment workload.
+	// UserProvided uses the serving cert and CA bundle referenced by UserProvidedCert,
+	// rotation is then owned by whoever maintains them.
+	// Defaults to SelfSigned.
+	CertSource *runoncedurationoverridev1.CertSource `json:"certSource,omitempty"`
+	// UserProvidedCert references the serving cert and CA bundle of the UserProvided
+	// cert source. Both live in the operand namespace.
+	UserProvidedCert *UserProvidedCertConfigApplyConfiguration `json:"userProvidedCert,omitempty"`
+	// Certificate configures the self-signed serving certs generated by the operator.
+	// It is ignored by the ServiceCA cert source.
+	Certificate *CertificateConfigApplyConfiguration `json:"certificate,omitempty"`
+	// OperandPlacement configures where the webhook server pods are scheduled and
+	// the resources they get. Changes roll out the workload.
+	OperandPlacement *OperandPlacementApplyConfiguration `json:"operandPlacement,omitempty"`
 }
 
 // RunOnceDurationOverrideSpecApplyConfiguration constructs a declarative configuration of the RunOnceDurationOverrideSpec type for use with
@@ -68,3 +104,67 @@ func (b *RunOnceDurationOverrideSpecApplyConfiguration) WithRunOnceDurationOverr
 	b.RunOnceDurationOverrideConfig = value
 	return b
 }
+
+// WithWebhook sets the Webhook field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Webhook field is set to the value of the last call.
+func (b *RunOnceDurationOverrideSpecApplyConfiguration) WithWebhook(value *WebhookConfigApplyConfiguration) *RunOnceDurationOverrideSpecApplyConfiguration {
+	b.Webhook = value
+	return b
+}
+
+// WithDeploymentMode sets the DeploymentMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeploymentMode field is set to the value of the last call.
+func (b *RunOnceDurationOverrideSpecApplyConfiguration) WithDeploymentMode(value runoncedurationoverridev1.DeploymentMode) *RunOnceDurationOverrideSpecApplyConfiguration {
+	b.DeploymentMode = &value
+	return b
+}
+
+// WithWorkload sets the Workload field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Workload field is set to the value of the last call.
+func (b *RunOnceDurationOverrideSpecApplyConfiguration) WithWorkload(value runoncedurationoverridev1.WorkloadType) *RunOnceDurationOverrideSpecApplyConfiguration {
+	b.Workload = &value
+	return b
+}
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *RunOnceDurationOverrideSpecApplyConfiguration) WithReplicas(value int32) *RunOnceDurationOverrideSpecApplyConfiguration {
+	b.Replicas = &value
+	return b
+}
+
+// WithCertSource sets the CertSource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CertSource field is set to the value of the last call.
+func (b *RunOnceDurationOverrideSpecApplyConfiguration) WithCertSource(value runoncedurationoverridev1.CertSource) *RunOnceDurationOverrideSpecApplyConfiguration {
+	b.CertSource = &value
+	return b
+}
+
+// WithUserProvidedCert sets the UserProvidedCert field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UserProvidedCert field is set to the value of the last call.
+func (b *RunOnceDurationOverrideSpecApplyConfiguration) WithUserProvidedCert(value *UserProvidedCertConfigApplyConfiguration) *RunOnceDurationOverrideSpecApplyConfiguration {
+	b.UserProvidedCert = value
+	return b
+}
+
+// WithCertificate sets the Certificate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Certificate field is set to the value of the last call.
+func (b *RunOnceDurationOverrideSpecApplyConfiguration) WithCertificate(value *CertificateConfigApplyConfiguration) *RunOnceDurationOverrideSpecApplyConfiguration {
+	b.Certificate = value
+	return b
+}
+
+// WithOperandPlacement sets the OperandPlacement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OperandPlacement field is set to the value of the last call.
+func (b *RunOnceDurationOverrideSpecApplyConfiguration) WithOperandPlacement(value *OperandPlacementApplyConfiguration) *RunOnceDurationOverrideSpecApplyConfiguration {
+	b.OperandPlacement = value
+	return b
+}
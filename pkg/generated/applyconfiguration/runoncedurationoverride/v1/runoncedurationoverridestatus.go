@@ -4,6 +4,7 @@ package v1
 
 import (
 	operatorv1 "github.com/openshift/client-go/operator/applyconfigurations/operator/v1"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -17,6 +18,16 @@ type RunOnceDurationOverrideStatusApplyConfiguration struct {
 	Image     *string                                                `json:"image,omitempty"`
 	// CertsRotateAt is the time the serving certs will be rotated at.
 	CertsRotateAt *metav1.Time `json:"certsRotateAt,omitempty"`
+	// DeploymentMode is the deployment mode currently in effect.
+	DeploymentMode *runoncedurationoverridev1.DeploymentMode `json:"deploymentMode,omitempty"`
+	// Workload is the workload currently running the admission webhook server.
+	Workload *runoncedurationoverridev1.WorkloadType `json:"workload,omitempty"`
+	// CertSource is the issuer of the serving cert currently in use.
+	CertSource *runoncedurationoverridev1.CertSource `json:"certSource,omitempty"`
+	// CertRotation tracks the staged rotation of the self-signed serving certs.
+	CertRotation *CertRotationStatusApplyConfiguration `json:"certRotation,omitempty"`
+	// Certificates describes the serving cert in use and the CA that signed it.
+	Certificates *CertificatesStatusApplyConfiguration `json:"certificates,omitempty"`
 }
 
 // RunOnceDurationOverrideStatusApplyConfiguration constructs a declarative configuration of the RunOnceDurationOverrideStatus type for use with
@@ -114,3 +125,43 @@ func (b *RunOnceDurationOverrideStatusApplyConfiguration) WithCertsRotateAt(valu
 	b.CertsRotateAt = &value
 	return b
 }
+
+// WithDeploymentMode sets the DeploymentMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeploymentMode field is set to the value of the last call.
+func (b *RunOnceDurationOverrideStatusApplyConfiguration) WithDeploymentMode(value runoncedurationoverridev1.DeploymentMode) *RunOnceDurationOverrideStatusApplyConfiguration {
+	b.DeploymentMode = &value
+	return b
+}
+
+// WithWorkload sets the Workload field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Workload field is set to the value of the last call.
+func (b *RunOnceDurationOverrideStatusApplyConfiguration) WithWorkload(value runoncedurationoverridev1.WorkloadType) *RunOnceDurationOverrideStatusApplyConfiguration {
+	b.Workload = &value
+	return b
+}
+
+// WithCertSource sets the CertSource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CertSource field is set to the value of the last call.
+func (b *RunOnceDurationOverrideStatusApplyConfiguration) WithCertSource(value runoncedurationoverridev1.CertSource) *RunOnceDurationOverrideStatusApplyConfiguration {
+	b.CertSource = &value
+	return b
+}
+
+// WithCertRotation sets the CertRotation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CertRotation field is set to the value of the last call.
+func (b *RunOnceDurationOverrideStatusApplyConfiguration) WithCertRotation(value *CertRotationStatusApplyConfiguration) *RunOnceDurationOverrideStatusApplyConfiguration {
+	b.CertRotation = value
+	return b
+}
+
+// WithCertificates sets the Certificates field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Certificates field is set to the value of the last call.
+func (b *RunOnceDurationOverrideStatusApplyConfiguration) WithCertificates(value *CertificatesStatusApplyConfiguration) *RunOnceDurationOverrideStatusApplyConfiguration {
+	b.Certificates = value
+	return b
+}
@@ -0,0 +1,38 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+// UserProvidedCertConfigApplyConfiguration represents a declarative configuration of the UserProvidedCertConfig type for use
+// with apply.
+//
+// UserProvidedCertConfig references a serving cert and CA bundle issued outside of the operator.
+type UserProvidedCertConfigApplyConfiguration struct {
+	// SecretName is the name of the kubernetes.io/tls Secret holding the serving
+	// cert (tls.crt) and its private key (tls.key).
+	SecretName *string `json:"secretName,omitempty"`
+	// CAConfigMapName is the name of the ConfigMap holding the PEM encoded CA
+	// that signed the serving cert, under the ca-bundle.crt key.
+	CAConfigMapName *string `json:"caConfigMapName,omitempty"`
+}
+
+// UserProvidedCertConfigApplyConfiguration constructs a declarative configuration of the UserProvidedCertConfig type for use with
+// apply.
+func UserProvidedCertConfig() *UserProvidedCertConfigApplyConfiguration {
+	return &UserProvidedCertConfigApplyConfiguration{}
+}
+
+// WithSecretName sets the SecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretName field is set to the value of the last call.
+func (b *UserProvidedCertConfigApplyConfiguration) WithSecretName(value string) *UserProvidedCertConfigApplyConfiguration {
+	b.SecretName = &value
+	return b
+}
+
+// WithCAConfigMapName sets the CAConfigMapName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CAConfigMapName field is set to the value of the last call.
+func (b *UserProvidedCertConfigApplyConfiguration) WithCAConfigMapName(value string) *UserProvidedCertConfigApplyConfiguration {
+	b.CAConfigMapName = &value
+	return b
+}
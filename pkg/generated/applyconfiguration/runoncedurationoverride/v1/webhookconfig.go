@@ -0,0 +1,67 @@
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// WebhookConfigApplyConfiguration represents a declarative configuration of the WebhookConfig type for use
+// with apply.
+//
+// WebhookConfig holds the registration settings of the admission webhook.
+type WebhookConfigApplyConfiguration struct {
+	// FailurePolicy defines how the API server handles errors calling the webhook.
+	// Allowed values are Fail and Ignore. Defaults to Fail.
+	FailurePolicy *admissionregistrationv1.FailurePolicyType `json:"failurePolicy,omitempty"`
+	// TimeoutSeconds is the time the API server waits for the webhook to respond.
+	// It must be between 1 and 30 seconds. Defaults to 5 seconds.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// NamespaceSelector selects the namespaces whose pods are sent to the webhook.
+	// Namespaces with openshift.io/run-level 0 or 1 are always excluded.
+	// Defaults to namespaces labeled
+	// runoncedurationoverrides.admission.runoncedurationoverride.openshift.io/enabled=true.
+	NamespaceSelector *metav1.LabelSelectorApplyConfiguration `json:"namespaceSelector,omitempty"`
+	// ObjectSelector selects the pods that are sent to the webhook by their labels.
+	// Defaults to all pods in the selected namespaces.
+	ObjectSelector *metav1.LabelSelectorApplyConfiguration `json:"objectSelector,omitempty"`
+}
+
+// WebhookConfigApplyConfiguration constructs a declarative configuration of the WebhookConfig type for use with
+// apply.
+func WebhookConfig() *WebhookConfigApplyConfiguration {
+	return &WebhookConfigApplyConfiguration{}
+}
+
+// WithFailurePolicy sets the FailurePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailurePolicy field is set to the value of the last call.
+func (b *WebhookConfigApplyConfiguration) WithFailurePolicy(value admissionregistrationv1.FailurePolicyType) *WebhookConfigApplyConfiguration {
+	b.FailurePolicy = &value
+	return b
+}
+
+// WithTimeoutSeconds sets the TimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeoutSeconds field is set to the value of the last call.
+func (b *WebhookConfigApplyConfiguration) WithTimeoutSeconds(value int32) *WebhookConfigApplyConfiguration {
+	b.TimeoutSeconds = &value
+	return b
+}
+
+// WithNamespaceSelector sets the NamespaceSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamespaceSelector field is set to the value of the last call.
+func (b *WebhookConfigApplyConfiguration) WithNamespaceSelector(value *metav1.LabelSelectorApplyConfiguration) *WebhookConfigApplyConfiguration {
+	b.NamespaceSelector = value
+	return b
+}
+
+// WithObjectSelector sets the ObjectSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObjectSelector field is set to the value of the last call.
+func (b *WebhookConfigApplyConfiguration) WithObjectSelector(value *metav1.LabelSelectorApplyConfiguration) *WebhookConfigApplyConfiguration {
+	b.ObjectSelector = value
+	return b
+}
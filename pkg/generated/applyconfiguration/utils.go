@@ -16,6 +16,18 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=operator.openshift.io, Version=v1
+	case v1.SchemeGroupVersion.WithKind("CertificateConfig"):
+		return &runoncedurationoverridev1.CertificateConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CertificatesStatus"):
+		return &runoncedurationoverridev1.CertificatesStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CertificateStatus"):
+		return &runoncedurationoverridev1.CertificateStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CertRotationStatus"):
+		return &runoncedurationoverridev1.CertRotationStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("NamespaceActiveDeadlineOverride"):
+		return &runoncedurationoverridev1.NamespaceActiveDeadlineOverrideApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OperandPlacement"):
+		return &runoncedurationoverridev1.OperandPlacementApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RunOnceDurationOverride"):
 		return &runoncedurationoverridev1.RunOnceDurationOverrideApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RunOnceDurationOverrideConfig"):
@@ -30,6 +42,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &runoncedurationoverridev1.RunOnceDurationOverrideSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RunOnceDurationOverrideStatus"):
 		return &runoncedurationoverridev1.RunOnceDurationOverrideStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("UserProvidedCertConfig"):
+		return &runoncedurationoverridev1.UserProvidedCertConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WebhookConfig"):
+		return &runoncedurationoverridev1.WebhookConfigApplyConfiguration{}
 
 	}
 	return nil
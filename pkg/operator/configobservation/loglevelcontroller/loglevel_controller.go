@@ -0,0 +1,76 @@
+package loglevelcontroller
+
+import (
+	"context"
+	"strconv"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+	"github.com/openshift/run-once-duration-override-operator/pkg/operator/loglevel"
+)
+
+// LogLevelController applies spec.operatorLogLevel of the operator CR to the
+// klog verbosity of the running operator process.
+type LogLevelController struct {
+	factory.Controller
+}
+
+func NewLogLevelController(
+	operatorClient v1helpers.OperatorClient,
+	eventRecorder events.Recorder,
+) *LogLevelController {
+	c := &logLevelController{
+		operatorClient: operatorClient,
+	}
+
+	return &LogLevelController{
+		Controller: factory.New().
+			WithInformers(operatorClient.Informer()).
+			WithSync(c.sync).
+			ToController("LogLevelController", eventRecorder),
+	}
+}
+
+type logLevelController struct {
+	operatorClient v1helpers.OperatorClient
+}
+
+func (c *logLevelController) sync(ctx context.Context, syncCtx factory.SyncContext) error {
+	spec, _, _, err := c.operatorClient.GetOperatorState()
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Keep the verbosity set on the command line until the CR shows up.
+			return nil
+		}
+		return err
+	}
+
+	desired := loglevel.LogLevelToVerbosity(spec.OperatorLogLevel)
+	current := CurrentVerbosity()
+	if desired == current {
+		return nil
+	}
+
+	var level klog.Level
+	if err := level.Set(strconv.Itoa(desired)); err != nil {
+		syncCtx.Recorder().Warningf("OperatorLogLevelChangeFailed", "Unable to change operator log level from %d to %d: %v", current, desired, err)
+		return err
+	}
+
+	syncCtx.Recorder().Eventf("OperatorLogLevelChange", "Operator log level changed from %d to %d (%q)", current, desired, spec.OperatorLogLevel)
+	return nil
+}
+
+// CurrentVerbosity returns the highest klog verbosity that is currently enabled.
+func CurrentVerbosity() int {
+	for i := 10; i > 0; i-- {
+		if klog.V(klog.Level(i)).Enabled() {
+			return i
+		}
+	}
+	return 0
+}
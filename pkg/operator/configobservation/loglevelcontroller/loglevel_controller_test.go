@@ -0,0 +1,51 @@
+package loglevelcontroller
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/controller/factory"
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+)
+
+func TestLogLevelController(t *testing.T) {
+	original := CurrentVerbosity()
+	defer func() {
+		var level klog.Level
+		_ = level.Set(strconv.Itoa(original))
+	}()
+
+	tests := []struct {
+		logLevel operatorv1.LogLevel
+		expected int
+	}{
+		{logLevel: operatorv1.Debug, expected: 4},
+		{logLevel: operatorv1.TraceAll, expected: 8},
+		{logLevel: operatorv1.Normal, expected: 2},
+		{logLevel: operatorv1.Trace, expected: 6},
+		{logLevel: "", expected: 2},
+	}
+
+	for _, tt := range tests {
+		operatorClient := v1helpers.NewFakeOperatorClient(&operatorv1.OperatorSpec{
+			ManagementState:  operatorv1.Managed,
+			OperatorLogLevel: tt.logLevel,
+		}, &operatorv1.OperatorStatus{}, nil)
+		recorder := events.NewInMemoryRecorder("test", clock.RealClock{})
+
+		c := &logLevelController{operatorClient: operatorClient}
+		if err := c.sync(context.TODO(), factory.NewSyncContext("test", recorder)); err != nil {
+			t.Fatalf("logLevel=%q unexpected error: %v", tt.logLevel, err)
+		}
+
+		if got := CurrentVerbosity(); got != tt.expected {
+			t.Errorf("logLevel=%q expected verbosity %d, got %d", tt.logLevel, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package eventrecorder
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	operatorscheme "github.com/openshift/run-once-duration-override-operator/pkg/generated/clientset/versioned/scheme"
+)
+
+// New returns a recorder of Events on any object, such as the cluster scoped
+// RunOnceDurationOverride the operator reconciles. The library-go recorder is
+// bound to a single object, the operator Deployment.
+//
+// Events are aggregated and rate-limited with the correlator options library-go
+// uses for the operator Deployment. The returned function stops the recorder.
+func New(client kubernetes.Interface, component string) (record.EventRecorder, func()) {
+	broadcaster := record.NewBroadcasterWithCorrelatorOptions(events.RecommendedClusterSingletonCorrelatorOptions())
+	broadcaster.StartRecordingToSink(&corev1client.EventSinkImpl{Interface: client.CoreV1().Events("")})
+
+	return broadcaster.NewRecorder(operatorscheme.Scheme, corev1.EventSource{Component: component}), broadcaster.Shutdown
+}
@@ -0,0 +1,31 @@
+package loglevel
+
+import (
+	operatorv1 "github.com/openshift/api/operator/v1"
+)
+
+// LogLevelToVerbosity maps an intent based log level to a klog verbosity.
+// An empty or unknown level is treated as Normal.
+func LogLevelToVerbosity(logLevel operatorv1.LogLevel) int {
+	switch logLevel {
+	case operatorv1.Debug:
+		return 4
+	case operatorv1.Trace:
+		return 6
+	case operatorv1.TraceAll:
+		return 8
+	default:
+		return 2
+	}
+}
+
+// OperandLogLevelToVerbosity maps the log level of the webhook server to a klog
+// verbosity. An empty level keeps the verbosity of 3 the webhook server ran
+// with before spec.logLevel existed, so that upgrading does not roll it out.
+func OperandLogLevelToVerbosity(logLevel operatorv1.LogLevel) int {
+	if logLevel == "" {
+		return 3
+	}
+
+	return LogLevelToVerbosity(logLevel)
+}
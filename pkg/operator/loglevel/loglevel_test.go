@@ -0,0 +1,44 @@
+package loglevel
+
+import (
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+)
+
+func TestLogLevelToVerbosity(t *testing.T) {
+	tests := []struct {
+		logLevel operatorv1.LogLevel
+		expected int
+	}{
+		{logLevel: "", expected: 2},
+		{logLevel: operatorv1.Normal, expected: 2},
+		{logLevel: operatorv1.Debug, expected: 4},
+		{logLevel: operatorv1.Trace, expected: 6},
+		{logLevel: operatorv1.TraceAll, expected: 8},
+		{logLevel: "Bogus", expected: 2},
+	}
+
+	for _, tt := range tests {
+		if got := LogLevelToVerbosity(tt.logLevel); got != tt.expected {
+			t.Errorf("logLevel=%q expected verbosity %d, got %d", tt.logLevel, tt.expected, got)
+		}
+	}
+}
+
+func TestOperandLogLevelToVerbosity(t *testing.T) {
+	tests := []struct {
+		logLevel operatorv1.LogLevel
+		expected int
+	}{
+		{logLevel: "", expected: 3},
+		{logLevel: operatorv1.Normal, expected: 2},
+		{logLevel: operatorv1.Debug, expected: 4},
+	}
+
+	for _, tt := range tests {
+		if got := OperandLogLevelToVerbosity(tt.logLevel); got != tt.expected {
+			t.Errorf("logLevel=%q expected verbosity %d, got %d", tt.logLevel, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	namespace = "runoncedurationoverride"
+)
+
+var (
+	HandlerReconcileTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Name:           "handler_reconcile_total",
+			Help:           "Number of times a handler of the reconcile chain has run.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"handler"},
+	)
+
+	HandlerReconcileDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      namespace,
+			Name:           "handler_reconcile_duration_seconds",
+			Help:           "Time a handler of the reconcile chain took to run.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 15),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"handler"},
+	)
+
+	HandlerErrorsTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Name:           "handler_errors_total",
+			Help:           "Number of errors returned by a handler of the reconcile chain, by reason.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"handler", "reason"},
+	)
+
+	WorkloadAvailable = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      namespace,
+			Name:           "workload_available",
+			Help:           "Whether the workload running the webhook server is available (1) or not (0).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"workload"},
+	)
+
+	ConfigHashChangesTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Name:           "config_hash_changes_total",
+			Help:           "Number of times a hash recorded in status has changed, by hash.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"hash"},
+	)
+
+	servingCertExpiry = &servingCertExpiryCollector{}
+
+	registerOnce sync.Once
+)
+
+// Register registers the operator metrics with the legacy registry served on
+// /metrics of the operator.
+func Register() {
+	registerOnce.Do(func() {
+		legacyregistry.MustRegister(
+			HandlerReconcileTotal,
+			HandlerReconcileDuration,
+			HandlerErrorsTotal,
+			WorkloadAvailable,
+			ConfigHashChangesTotal,
+		)
+		legacyregistry.CustomMustRegister(servingCertExpiry)
+	})
+}
+
+// ObserveHandler records a run of the given handler. reason is the reason of
+// the error returned by the handler, empty if the handler succeeded.
+func ObserveHandler(handler string, duration time.Duration, reason string) {
+	HandlerReconcileTotal.WithLabelValues(handler).Inc()
+	HandlerReconcileDuration.WithLabelValues(handler).Observe(duration.Seconds())
+	if reason != "" {
+		HandlerErrorsTotal.WithLabelValues(handler, reason).Inc()
+	}
+}
+
+// SetWorkloadAvailable records the availability of the given workload, and
+// drops the series of any other workload.
+func SetWorkloadAvailable(workload string, available bool) {
+	WorkloadAvailable.Reset()
+
+	value := 0.0
+	if available {
+		value = 1
+	}
+	WorkloadAvailable.WithLabelValues(workload).Set(value)
+}
+
+// SetServingCertNotAfter records the expiry of the serving cert. The zero time
+// stops reporting the expiry.
+func SetServingCertNotAfter(notAfter time.Time) {
+	servingCertExpiry.Set(notAfter)
+}
+
+var servingCertExpiryDesc = metrics.NewDesc(
+	metrics.BuildFQName(namespace, "", "serving_cert_expiry_seconds"),
+	"Number of seconds until the serving cert of the webhook server expires.",
+	nil, nil,
+	metrics.ALPHA,
+	"",
+)
+
+// servingCertExpiryCollector computes the seconds to expiry at scrape time, so
+// the value does not go stale between two reconciles.
+type servingCertExpiryCollector struct {
+	metrics.BaseStableCollector
+
+	lock     sync.RWMutex
+	notAfter time.Time
+}
+
+func (c *servingCertExpiryCollector) Set(notAfter time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.notAfter = notAfter
+}
+
+func (c *servingCertExpiryCollector) DescribeWithStability(ch chan<- *metrics.Desc) {
+	ch <- servingCertExpiryDesc
+}
+
+func (c *servingCertExpiryCollector) CollectWithStability(ch chan<- metrics.Metric) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.notAfter.IsZero() {
+		return
+	}
+
+	ch <- metrics.NewLazyConstMetric(servingCertExpiryDesc, metrics.GaugeValue, time.Until(c.notAfter).Seconds())
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/testutil"
+)
+
+func TestObserveHandler(t *testing.T) {
+	HandlerReconcileTotal.Reset()
+	HandlerErrorsTotal.Reset()
+	Register()
+
+	ObserveHandler("certReadyHandler", time.Millisecond, "")
+	ObserveHandler("certReadyHandler", time.Millisecond, "CertNotAvailable")
+
+	count, err := testutil.GetCounterMetricValue(HandlerReconcileTotal.WithLabelValues("certReadyHandler"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 reconciles, got %v", count)
+	}
+
+	errors, err := testutil.GetCounterMetricValue(HandlerErrorsTotal.WithLabelValues("certReadyHandler", "CertNotAvailable"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errors != 1 {
+		t.Errorf("expected 1 error, got %v", errors)
+	}
+}
+
+func TestSetWorkloadAvailable(t *testing.T) {
+	Register()
+
+	SetWorkloadAvailable("DaemonSet", true)
+	SetWorkloadAvailable("Deployment", false)
+
+	if err := testutil.CollectAndCompare(WorkloadAvailable, strings.NewReader(`
+# HELP runoncedurationoverride_workload_available [ALPHA] Whether the workload running the webhook server is available (1) or not (0).
+# TYPE runoncedurationoverride_workload_available gauge
+runoncedurationoverride_workload_available{workload="Deployment"} 0
+`), "runoncedurationoverride_workload_available"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestServingCertExpiry(t *testing.T) {
+	collector := &servingCertExpiryCollector{}
+	registry := metrics.NewKubeRegistry()
+	registry.CustomMustRegister(collector)
+
+	count := func() int {
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return len(families)
+	}
+
+	if got := count(); got != 0 {
+		t.Errorf("expected no series before the serving cert is known, got %d", got)
+	}
+
+	collector.Set(time.Now().Add(time.Hour))
+	if got := count(); got != 1 {
+		t.Errorf("expected one series, got %d", got)
+	}
+
+	collector.Set(time.Time{})
+	if got := count(); got != 0 {
+		t.Errorf("expected no series once the serving cert is gone, got %d", got)
+	}
+}
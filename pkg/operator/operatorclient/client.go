@@ -30,6 +30,9 @@ const (
 	OperatorNamespace       = "openshift-run-once-duration-override-operator"
 	OperatorConfigName      = "cluster"
 	OperatorOwnerAnnotation = "runoncedurationoverride.operator.openshift.io/owner"
+	// OperatorFinalizer holds the deletion of the RunOnceDurationOverride until
+	// the operator has removed the cluster scoped resources of the operand.
+	OperatorFinalizer = "runoncedurationoverride.operator.openshift.io/cleanup"
 )
 
 type RunOnceDurationOverrideOperatorClient interface {
@@ -302,7 +305,7 @@ func (c *RunOnceDurationOverrideClient) convertApplyConfigToFullStatus(
 	applyConfig := runoncedurationoverrideapplyconfiguration.RunOnceDurationOverrideStatus()
 	applyConfig.OperatorStatusApplyConfiguration = *desiredOperatorStatus
 
-	if currentStatus.Hash.Configuration != "" || currentStatus.Hash.ServingCert != "" || currentStatus.Hash.ObservedConfig != "" {
+	if currentStatus.Hash.Configuration != "" || currentStatus.Hash.ServingCert != "" || currentStatus.Hash.ObservedConfig != "" || currentStatus.Hash.OperandPlacement != "" {
 		hashApplyConfig := runoncedurationoverrideapplyconfiguration.RunOnceDurationOverrideResourceHash()
 		if currentStatus.Hash.Configuration != "" {
 			hashApplyConfig.WithConfiguration(currentStatus.Hash.Configuration)
@@ -313,6 +316,9 @@ func (c *RunOnceDurationOverrideClient) convertApplyConfigToFullStatus(
 		if currentStatus.Hash.ObservedConfig != "" {
 			hashApplyConfig.WithObservedConfig(currentStatus.Hash.ObservedConfig)
 		}
+		if currentStatus.Hash.OperandPlacement != "" {
+			hashApplyConfig.WithOperandPlacement(currentStatus.Hash.OperandPlacement)
+		}
 		applyConfig.WithHash(hashApplyConfig)
 	}
 
@@ -5,22 +5,25 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
-	"k8s.io/utils/clock"
 
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
 	configinformers "github.com/openshift/client-go/config/informers/externalversions"
 	"github.com/openshift/library-go/pkg/controller/controllercmd"
-	"github.com/openshift/library-go/pkg/operator/events"
 	"github.com/openshift/library-go/pkg/operator/resourcesynccontroller"
 	"github.com/openshift/library-go/pkg/operator/v1helpers"
 	"github.com/openshift/run-once-duration-override-operator/pkg/generated/clientset/versioned"
 	operatorinformers "github.com/openshift/run-once-duration-override-operator/pkg/generated/informers/externalversions"
 	"github.com/openshift/run-once-duration-override-operator/pkg/operator/configobservation/configobservercontroller"
+	"github.com/openshift/run-once-duration-override-operator/pkg/operator/configobservation/loglevelcontroller"
+	"github.com/openshift/run-once-duration-override-operator/pkg/operator/eventrecorder"
+	"github.com/openshift/run-once-duration-override-operator/pkg/operator/metrics"
 	"github.com/openshift/run-once-duration-override-operator/pkg/operator/operatorclient"
 	"github.com/openshift/run-once-duration-override-operator/pkg/operator/targetconfigcontroller"
 	"github.com/openshift/run-once-duration-override-operator/pkg/runtime"
@@ -33,9 +36,14 @@ const (
 	// basically a cluster singleton.
 	DefaultCR = "cluster"
 
-	// Default worker count is 1.
+	// Default worker count is 1. The controller reconciles a single queue key,
+	// the workqueue never hands it to two workers at the same time.
 	DefaultWorkerCount = 1
 
+	// HealthzBindAddress is where the health check is served. It is served by
+	// every replica, whether or not it holds the leader lease.
+	HealthzBindAddress = ":8080"
+
 	// Default ResyncPeriod for primary (RunOnceDurationOverride objects)
 	DefaultResyncPeriodPrimaryResource = 1 * time.Hour
 
@@ -49,6 +57,20 @@ const (
 	OperandVersionEnvName = "OPERAND_VERSION"
 )
 
+// ServeHealthz serves a simple HTTP health check on the given address.
+func ServeHealthz(address string) {
+	healthMux := http.NewServeMux()
+	healthMux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	if err := http.ListenAndServe(address, healthMux); err != nil {
+		klog.Errorf("[operator] failed to serve health check on %s - %s", address, err.Error())
+	}
+}
+
+// RunOperator runs the operator once it holds the leader lease. It returns after all
+// controllers have stopped, so that the lease is released only when nothing
+// reconciles anymore.
 func RunOperator(ctx context.Context, cc *controllercmd.ControllerContext) error {
 	defer klog.V(1).Infof("[operator] exiting")
 
@@ -72,6 +94,11 @@ func RunOperator(ctx context.Context, cc *controllercmd.ControllerContext) error
 		return fmt.Errorf("failed to construct client for kubernetes - %s", err.Error())
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(cc.KubeConfig)
+	if err != nil {
+		return fmt.Errorf("failed to construct dynamic client - %s", err.Error())
+	}
+
 	operandContext := runtime.NewOperandContext(operatorclient.OperatorName, operatorclient.OperatorNamespace, DefaultCR, operandImage, operandVersion)
 
 	kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(
@@ -96,7 +123,11 @@ func RunOperator(ctx context.Context, cc *controllercmd.ControllerContext) error
 	}
 	configInformers := configinformers.NewSharedInformerFactory(configClient, 10*time.Minute)
 
-	recorder := events.NewLoggingEventRecorder(operatorclient.OperatorName, clock.RealClock{})
+	// Events from resourceapply and the library-go controllers are recorded on
+	// the operator Deployment, the handlers also record Events on the CR.
+	recorder := cc.EventRecorder
+	objectRecorder, stopObjectRecorder := eventrecorder.New(kubeClient, operatorclient.OperatorName)
+	defer stopObjectRecorder()
 
 	runOnceDurationOverrideClient := &operatorclient.RunOnceDurationOverrideClient{
 		Ctx:                             ctx,
@@ -122,13 +153,20 @@ func RunOperator(ctx context.Context, cc *controllercmd.ControllerContext) error
 		recorder,
 	)
 
-	c := targetconfigcontroller.NewTargetConfigController(
+	logLevelController := loglevelcontroller.NewLogLevelController(
+		operatorClientWrapper,
+		recorder,
+	)
+
+	targetConfigControllers := targetconfigcontroller.NewTargetConfigControllers(
 		runOnceDurationOverrideClient,
 		kubeClient,
+		dynamicClient,
 		operandContext,
 		kubeInformerFactory,
 		operatorInformerFactory,
 		recorder,
+		objectRecorder,
 	)
 
 	kubeInformerFactory.Start(ctx.Done())
@@ -136,19 +174,31 @@ func RunOperator(ctx context.Context, cc *controllercmd.ControllerContext) error
 	operatorInformerFactory.Start(ctx.Done())
 	configInformers.Start(ctx.Done())
 
-	// Serve a simple HTTP health check.
-	healthMux := http.NewServeMux()
-	healthMux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
-	go http.ListenAndServe(":8080", healthMux)
+	// The metrics are served on /metrics of the secured endpoint set up by
+	// controllercmd.
+	metrics.Register()
 
 	klog.V(1).Infof("operator is starting controllers")
 
-	go resourceSyncController.Run(ctx, 1)
-	go configObserver.Run(ctx, 1)
-	go c.Run(ctx, DefaultWorkerCount)
+	runs := []func(){
+		func() { resourceSyncController.Run(ctx, 1) },
+		func() { configObserver.Run(ctx, 1) },
+		func() { logLevelController.Run(ctx, 1) },
+	}
+	for _, c := range targetConfigControllers {
+		runs = append(runs, func() { c.Run(ctx, DefaultWorkerCount) })
+	}
+
+	var wg sync.WaitGroup
+	for _, run := range runs {
+		wg.Add(1)
+		go func(run func()) {
+			defer wg.Done()
+			run()
+		}(run)
+	}
 
 	<-ctx.Done()
+	wg.Wait()
 	return nil
 }
@@ -10,13 +10,18 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
@@ -29,6 +34,7 @@ import (
 	"github.com/openshift/library-go/pkg/operator/resourcesynccontroller"
 	"github.com/openshift/library-go/pkg/operator/v1helpers"
 	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	applyfake "github.com/openshift/run-once-duration-override-operator/pkg/apply/fake"
 	fakeclientset "github.com/openshift/run-once-duration-override-operator/pkg/generated/clientset/versioned/fake"
 	operatorinformers "github.com/openshift/run-once-duration-override-operator/pkg/generated/informers/externalversions"
 	"github.com/openshift/run-once-duration-override-operator/pkg/operator/configobservation/configobservercontroller"
@@ -74,8 +80,8 @@ type testOperatorSetup struct {
 }
 
 type tlsTestControllers struct {
-	configObserver         *configobservercontroller.ConfigObserver
-	targetConfigController factory.Controller
+	configObserver          *configobservercontroller.ConfigObserver
+	targetConfigControllers []factory.Controller
 }
 
 // setupTLSTestControllers creates and initializes controllers for TLS configuration tests
@@ -98,13 +104,15 @@ func setupTLSTestControllers(t *testing.T, setup *testOperatorSetup) *tlsTestCon
 		setup.recorder,
 	)
 
-	targetConfigController := targetconfigcontroller.NewTargetConfigController(
+	targetConfigControllers := targetconfigcontroller.NewTargetConfigControllers(
 		setup.operatorClientWrapper,
 		setup.kubeClient,
+		applyfake.NewSimpleDynamicClient(runtime.NewScheme()),
 		setup.runtimeContext,
 		setup.kubeInformerFactory,
 		setup.operatorInformerFactory,
 		setup.recorder,
+		&record.FakeRecorder{},
 	)
 
 	// Start all informers
@@ -120,8 +128,8 @@ func setupTLSTestControllers(t *testing.T, setup *testOperatorSetup) *tlsTestCon
 	setup.operatorInformerFactory.WaitForCacheSync(setup.ctx.Done())
 
 	return &tlsTestControllers{
-		configObserver:         configObserver,
-		targetConfigController: targetConfigController,
+		configObserver:          configObserver,
+		targetConfigControllers: targetConfigControllers,
 	}
 }
 
@@ -134,10 +142,127 @@ func syncControllers(t *testing.T, setup *testOperatorSetup, controllers *tlsTes
 	}
 
 	for i := 0; i < iterations; i++ {
-		if err := controllers.targetConfigController.Sync(setup.ctx, &fakeSyncContext{recorder: setup.recorder}); err != nil {
+		if err := syncTargetConfigControllers(setup, controllers.targetConfigControllers); err != nil {
 			t.Logf("Sync iteration %d returned error: %v", i+1, err)
 		}
+
+		waitForListers(t, setup)
+	}
+}
+
+// syncTargetConfigControllers runs a sync of each of the target config
+// controllers, in order.
+func syncTargetConfigControllers(setup *testOperatorSetup, controllers []factory.Controller) error {
+	var errs []error
+	for _, c := range controllers {
+		if err := c.Sync(setup.ctx, &fakeSyncContext{recorder: setup.recorder}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// waitForListers waits until the listers the target config controllers read
+// from have caught up with the objects the previous sync wrote, so that the
+// next sync sees them.
+func waitForListers(t *testing.T, setup *testOperatorSetup) {
+	t.Helper()
+
+	ns := setup.namespace
+	kubeInformers := setup.kubeInformerFactory
+	err := wait.PollUntilContextTimeout(setup.ctx, 10*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+		crs, err := setup.operatorClient.RunOnceDurationOverrideV1().RunOnceDurationOverrides().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		cachedCRs, err := setup.operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides().Lister().List(labels.Everything())
+		if err != nil {
+			return false, err
+		}
+		daemonSets, err := setup.kubeClient.AppsV1().DaemonSets(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		cachedDaemonSets, err := kubeInformers.Apps().V1().DaemonSets().Lister().DaemonSets(ns).List(labels.Everything())
+		if err != nil {
+			return false, err
+		}
+		deployments, err := setup.kubeClient.AppsV1().Deployments(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		cachedDeployments, err := kubeInformers.Apps().V1().Deployments().Lister().Deployments(ns).List(labels.Everything())
+		if err != nil {
+			return false, err
+		}
+		secrets, err := setup.kubeClient.CoreV1().Secrets(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		cachedSecrets, err := kubeInformers.Core().V1().Secrets().Lister().Secrets(ns).List(labels.Everything())
+		if err != nil {
+			return false, err
+		}
+		configMaps, err := setup.kubeClient.CoreV1().ConfigMaps(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		cachedConfigMaps, err := kubeInformers.Core().V1().ConfigMaps().Lister().ConfigMaps(ns).List(labels.Everything())
+		if err != nil {
+			return false, err
+		}
+
+		return sameObjects(crs.Items, cachedCRs) &&
+			sameObjects(daemonSets.Items, cachedDaemonSets) &&
+			sameObjects(deployments.Items, cachedDeployments) &&
+			sameObjects(secrets.Items, cachedSecrets) &&
+			sameObjects(configMaps.Items, cachedConfigMaps), nil
+	})
+	if err != nil {
+		t.Fatalf("listers did not catch up with the clients: %v", err)
+	}
+}
+
+// waitForAPIServer waits until the config lister sees the given resource
+// version of the cluster APIServer.
+func waitForAPIServer(t *testing.T, setup *testOperatorSetup, resourceVersion string) {
+	t.Helper()
+
+	lister := setup.configInformers.Config().V1().APIServers().Lister()
+	err := wait.PollUntilContextTimeout(setup.ctx, 10*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+		apiServer, err := lister.Get("cluster")
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return apiServer.ResourceVersion == resourceVersion, nil
+	})
+	if err != nil {
+		t.Fatalf("config lister did not see APIServer resource version %s: %v", resourceVersion, err)
+	}
+}
+
+// sameObjects reports whether a lister holds the same objects as a list from
+// the client.
+func sameObjects[T any](items []T, cached []*T) bool {
+	if len(items) != len(cached) {
+		return false
+	}
+
+	byName := map[string]*T{}
+	for _, object := range cached {
+		byName[any(object).(metav1.Object).GetName()] = object
+	}
+	for i := range items {
+		object, ok := byName[any(&items[i]).(metav1.Object).GetName()]
+		if !ok || !apiequality.Semantic.DeepEqual(&items[i], object) {
+			return false
+		}
 	}
+	return true
 }
 
 type testOperatorOptions struct {
@@ -227,7 +352,7 @@ func setupTestOperatorWithOptions(t *testing.T, opts testOperatorOptions) *testO
 		initialResources = []runtime.Object{configMap, secret, caConfigMap, daemonSet}
 	}
 
-	fakeKubeClient := kubefake.NewSimpleClientset(initialResources...)
+	fakeKubeClient := kubefake.NewClientset(initialResources...)
 
 	// Add DaemonSet reactor if requested
 	if opts.addDaemonSetReadyReactor {
@@ -240,30 +365,13 @@ func setupTestOperatorWithOptions(t *testing.T, opts testOperatorOptions) *testO
 			ds.Status.NumberUnavailable = 0
 		}
 
-		fakeKubeClient.PrependReactor("create", "daemonsets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
-			createAction := action.(kubetesting.CreateAction)
-			ds := createAction.GetObject().(*appsv1.DaemonSet).DeepCopy()
-
-			err = fakeKubeClient.Tracker().Create(daemonSetGVR, ds, ds.Namespace)
-			if err != nil {
-				return true, nil, err
-			}
-
-			setDaemonSetReady(ds)
-			err = fakeKubeClient.Tracker().Update(daemonSetGVR, ds, ds.Namespace)
-
-			return true, ds, err
-		})
-
-		fakeKubeClient.PrependReactor("update", "daemonsets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
-			updateAction := action.(kubetesting.UpdateAction)
-			ds := updateAction.GetObject().(*appsv1.DaemonSet).DeepCopy()
-
-			err = fakeKubeClient.Tracker().Update(daemonSetGVR, ds, ds.Namespace)
+		fakeKubeClient.PrependReactor("patch", "daemonsets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+			_, object, err := kubetesting.ObjectReaction(fakeKubeClient.Tracker())(action)
 			if err != nil {
 				return true, nil, err
 			}
 
+			ds := object.(*appsv1.DaemonSet).DeepCopy()
 			setDaemonSetReady(ds)
 			err = fakeKubeClient.Tracker().Update(daemonSetGVR, ds, ds.Namespace)
 
@@ -403,17 +511,19 @@ func TestOperatorReconciliation(t *testing.T) {
 	defer setup.cancel()
 
 	// Create controller first - this registers informers via factory.New().WithFilteredEventsInformers
-	controller := targetconfigcontroller.NewTargetConfigController(
+	controllers := targetconfigcontroller.NewTargetConfigControllers(
 		&operatorclient.RunOnceDurationOverrideClient{
 			Ctx:                             setup.ctx,
 			RunOnceDurationOverrideInformer: setup.operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides(),
 			OperatorClient:                  setup.operatorClient.RunOnceDurationOverrideV1(),
 		},
 		setup.kubeClient,
+		applyfake.NewSimpleDynamicClient(runtime.NewScheme()),
 		setup.runtimeContext,
 		setup.kubeInformerFactory,
 		setup.operatorInformerFactory,
 		setup.recorder,
+		&record.FakeRecorder{},
 	)
 
 	// Start informers and wait for caches to sync
@@ -424,15 +534,16 @@ func TestOperatorReconciliation(t *testing.T) {
 
 	verifyResources(t, setup.ctx, setup.kubeClient, setup.namespace, setup.expectedNames, true)
 
-	for i := 0; i < 2; i++ {
-		if err := controller.Sync(setup.ctx, &fakeSyncContext{recorder: setup.recorder}); err != nil {
+	// Each controller reads what the others have applied through its listers,
+	// so it takes a few rounds for the operand to converge.
+	for i := 0; i < 5; i++ {
+		if err := syncTargetConfigControllers(setup, controllers); err != nil {
 			t.Logf("Sync %d returned error: %v", i+1, err)
 		} else {
 			t.Logf("Sync %d succeeded", i+1)
 		}
 
-		// Give watch events time to propagate to informers
-		time.Sleep(100 * time.Millisecond)
+		waitForListers(t, setup)
 	}
 
 	verifyResources(t, setup.ctx, setup.kubeClient, setup.namespace, setup.expectedNames, false)
@@ -785,8 +896,7 @@ func TestDaemonSetTLSConfiguration_ObservedConfigUpdate(t *testing.T) {
 		t.Fatalf("failed to update APIServer: %v", err)
 	}
 
-	// Give informer time to see the update
-	time.Sleep(100 * time.Millisecond)
+	waitForAPIServer(t, setup, updatedAPIServer.ResourceVersion)
 
 	// Sync controllers - this should detect ObservedConfig change and update DaemonSet
 	syncControllers(t, setup, controllers, 3)
@@ -896,7 +1006,7 @@ func TestDaemonSetTLSConfiguration_NoDuplicateArgs(t *testing.T) {
 		t.Fatalf("failed to update APIServer (first update): %v", err)
 	}
 
-	time.Sleep(100 * time.Millisecond)
+	waitForAPIServer(t, setup, updatedAPIServer1.ResourceVersion)
 
 	syncControllers(t, setup, controllers, 3)
 
@@ -939,7 +1049,7 @@ func TestDaemonSetTLSConfiguration_NoDuplicateArgs(t *testing.T) {
 		t.Fatalf("failed to update APIServer (second update): %v", err)
 	}
 
-	time.Sleep(100 * time.Millisecond)
+	waitForAPIServer(t, setup, updatedAPIServer2.ResourceVersion)
 
 	syncControllers(t, setup, controllers, 3)
 
@@ -0,0 +1,227 @@
+package targetconfigcontroller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+)
+
+// degradedGracePeriod is how long a reconcile error has to persist before the
+// operator reports Degraded=True, so that a single failing reconcile or a
+// rollout that completes in time does not mark the operator degraded.
+const degradedGracePeriod = 2 * time.Minute
+
+// setStandardConditions sets the Progressing, Degraded and Upgradeable
+// conditions from the outcome of the handler chain. The Available condition is
+// set by the handlers, and by sync when the chain succeeds.
+func setStandardConditions(original, current *appsv1.RunOnceDurationOverrideStatus, err error, now time.Time) {
+	setProgressingCondition(original, current, err)
+	setDegradedCondition(current, err, now)
+
+	// Nothing the operator manages blocks an upgrade of the operator.
+	v1helpers.SetOperatorCondition(&current.Conditions, operatorv1.OperatorCondition{
+		Type:   operatorv1.OperatorStatusTypeUpgradeable,
+		Status: operatorv1.ConditionTrue,
+		Reason: appsv1.AsExpected,
+	})
+}
+
+// setProgressingCondition reports Progressing=True when a hash that rolls out
+// the webhook server changed, or while the workload is rolling out. Otherwise
+// the condition set by the handlers is kept.
+func setProgressingCondition(original, current *appsv1.RunOnceDurationOverrideStatus, err error) {
+	if changed := changedHashes(original, current); len(changed) > 0 {
+		v1helpers.SetOperatorCondition(&current.Conditions, operatorv1.OperatorCondition{
+			Type:    operatorv1.OperatorStatusTypeProgressing,
+			Status:  operatorv1.ConditionTrue,
+			Reason:  appsv1.ConfigurationChanged,
+			Message: fmt.Sprintf("%s changed, rolling out the webhook server", strings.Join(changed, ", ")),
+		})
+		return
+	}
+
+	if GetReason(err) == appsv1.DeploymentNotReady {
+		v1helpers.SetOperatorCondition(&current.Conditions, operatorv1.OperatorCondition{
+			Type:    operatorv1.OperatorStatusTypeProgressing,
+			Status:  operatorv1.ConditionTrue,
+			Reason:  appsv1.RollingOut,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	if v1helpers.FindOperatorCondition(current.Conditions, operatorv1.OperatorStatusTypeProgressing) == nil {
+		v1helpers.SetOperatorCondition(&current.Conditions, operatorv1.OperatorCondition{
+			Type:   operatorv1.OperatorStatusTypeProgressing,
+			Status: operatorv1.ConditionFalse,
+			Reason: appsv1.AsExpected,
+		})
+	}
+}
+
+// setDegradedCondition reports Degraded=True with the reason of the handler
+// error once the error has persisted for degradedGracePeriod. The condition the
+// error sets keeps its transition time for as long as the error persists.
+func setDegradedCondition(current *appsv1.RunOnceDurationOverrideStatus, err error, now time.Time) {
+	if err == nil {
+		v1helpers.SetOperatorCondition(&current.Conditions, operatorv1.OperatorCondition{
+			Type:   operatorv1.OperatorStatusTypeDegraded,
+			Status: operatorv1.ConditionFalse,
+			Reason: appsv1.AsExpected,
+		})
+		return
+	}
+
+	failing := v1helpers.FindOperatorCondition(current.Conditions, GetConditionType(err))
+	if failing == nil || now.Sub(failing.LastTransitionTime.Time) < degradedGracePeriod {
+		// Keep reporting what was reported before, a handler may already have
+		// reported the operator degraded.
+		if v1helpers.FindOperatorCondition(current.Conditions, operatorv1.OperatorStatusTypeDegraded) == nil {
+			v1helpers.SetOperatorCondition(&current.Conditions, operatorv1.OperatorCondition{
+				Type:   operatorv1.OperatorStatusTypeDegraded,
+				Status: operatorv1.ConditionFalse,
+				Reason: appsv1.AsExpected,
+			})
+		}
+		return
+	}
+
+	reason := GetReason(err)
+	if reason == "" {
+		reason = "ReconciliationError"
+	}
+
+	v1helpers.SetOperatorCondition(&current.Conditions, operatorv1.OperatorCondition{
+		Type:    operatorv1.OperatorStatusTypeDegraded,
+		Status:  operatorv1.ConditionTrue,
+		Reason:  reason,
+		Message: err.Error(),
+	})
+}
+
+// changedHashes returns the names of the hashes that changed to a new value.
+func changedHashes(original, current *appsv1.RunOnceDurationOverrideStatus) []string {
+	var changed []string
+	if current.Hash.Configuration != "" && current.Hash.Configuration != original.Hash.Configuration {
+		changed = append(changed, "configuration")
+	}
+	if current.Hash.ServingCert != "" && current.Hash.ServingCert != original.Hash.ServingCert {
+		changed = append(changed, "serving cert")
+	}
+	if current.Hash.ObservedConfig != "" && current.Hash.ObservedConfig != original.Hash.ObservedConfig {
+		changed = append(changed, "observed config")
+	}
+	if current.Hash.OperandPlacement != "" && current.Hash.OperandPlacement != original.Hash.OperandPlacement {
+		changed = append(changed, "operand placement")
+	}
+
+	return changed
+}
+
+// areaConditionTypes are the conditions each sub-controller reports for its area
+// of the operand, prefixed with the name of the area.
+var areaConditionTypes = sets.New[string](
+	operatorv1.OperatorStatusTypeAvailable,
+	operatorv1.OperatorStatusTypeProgressing,
+	operatorv1.OperatorStatusTypeDegraded,
+	operatorv1.OperatorStatusTypeUpgradeable,
+	appsv1.InstallReadinessFailure,
+)
+
+// areaStatus returns the status as seen by the handler chain of the area with
+// the given condition prefix. The conditions of the area are reported without
+// their prefix, the operator conditions aggregated from all areas are left out.
+func areaStatus(status *appsv1.RunOnceDurationOverrideStatus, prefix string) *appsv1.RunOnceDurationOverrideStatus {
+	out := status.DeepCopy()
+	out.Conditions = nil
+	for _, condition := range status.Conditions {
+		if areaConditionTypes.Has(condition.Type) {
+			continue
+		}
+		if conditionType := strings.TrimPrefix(condition.Type, prefix); conditionType != condition.Type && areaConditionTypes.Has(conditionType) {
+			condition.Type = conditionType
+		}
+		out.Conditions = append(out.Conditions, condition)
+	}
+
+	return out
+}
+
+// prefixAreaConditions is the inverse of areaStatus, it adds the given prefix to
+// the conditions of the area.
+func prefixAreaConditions(status *appsv1.RunOnceDurationOverrideStatus, prefix string) {
+	for i := range status.Conditions {
+		if areaConditionTypes.Has(status.Conditions[i].Type) {
+			status.Conditions[i].Type = prefix + status.Conditions[i].Type
+		}
+	}
+}
+
+// setOperatorConditions sets the Available, Progressing, Degraded, Upgradeable
+// and InstallReadinessFailure conditions of the operator from the conditions of
+// the areas with the given prefixes. Available and Upgradeable are False if any
+// area reports them False, the other conditions are True if any area reports
+// them True.
+func setOperatorConditions(status *appsv1.RunOnceDurationOverrideStatus, prefixes []string) {
+	for _, conditionType := range sets.List(areaConditionTypes) {
+		want := operatorv1.ConditionTrue
+		if conditionType == operatorv1.OperatorStatusTypeAvailable || conditionType == operatorv1.OperatorStatusTypeUpgradeable {
+			want = operatorv1.ConditionFalse
+		}
+
+		var found, matching []operatorv1.OperatorCondition
+		var areas []string
+		for _, prefix := range prefixes {
+			condition := v1helpers.FindOperatorCondition(status.Conditions, prefix+conditionType)
+			if condition == nil {
+				continue
+			}
+			found = append(found, *condition)
+			if condition.Status == want {
+				matching = append(matching, *condition)
+				areas = append(areas, prefix)
+			}
+		}
+		if len(found) == 0 {
+			continue
+		}
+
+		// Without an area reporting the condition, the first area is used.
+		condition := found[0]
+		if len(matching) > 0 {
+			condition = matching[0]
+		}
+		if len(matching) > 1 {
+			messages := make([]string, 0, len(matching))
+			for i := range matching {
+				if matching[i].Message != "" {
+					messages = append(messages, fmt.Sprintf("%s: %s", areas[i], matching[i].Message))
+				}
+			}
+			condition.Message = strings.Join(messages, "\n")
+		}
+
+		v1helpers.SetOperatorCondition(&status.Conditions, operatorv1.OperatorCondition{
+			Type:    conditionType,
+			Status:  condition.Status,
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+}
+
+// keepTransitionTime keeps the last transition time of the condition of the
+// given type, if its status is the same as in original.
+func keepTransitionTime(original, current *appsv1.RunOnceDurationOverrideStatus, conditionType string) {
+	previous := v1helpers.FindOperatorCondition(original.Conditions, conditionType)
+	condition := v1helpers.FindOperatorCondition(current.Conditions, conditionType)
+	if previous != nil && condition != nil && previous.Status == condition.Status {
+		condition.LastTransitionTime = previous.LastTransitionTime
+	}
+}
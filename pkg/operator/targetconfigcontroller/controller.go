@@ -2,30 +2,46 @@ package targetconfigcontroller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
 	"github.com/openshift/library-go/pkg/operator/v1helpers"
 	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
 	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
 	"github.com/openshift/run-once-duration-override-operator/pkg/deploy"
 	operatorinformers "github.com/openshift/run-once-duration-override-operator/pkg/generated/informers/externalversions"
 	runoncedurationoverridev1listers "github.com/openshift/run-once-duration-override-operator/pkg/generated/listers/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/operator/metrics"
 	"github.com/openshift/run-once-duration-override-operator/pkg/operator/operatorclient"
 	operatorruntime "github.com/openshift/run-once-duration-override-operator/pkg/runtime"
 )
 
 const (
 	ControllerName = "runoncedurationoverride"
+
+	// The prefixes of the conditions the sub-controllers report for their area
+	// of the operand, for example CertManagementDegraded.
+	ConfigurationConditionPrefix       = "Configuration"
+	CertManagementConditionPrefix      = "CertManagement"
+	WorkloadRolloutConditionPrefix     = "WorkloadRollout"
+	WebhookRegistrationConditionPrefix = "WebhookRegistration"
 )
 
 var (
@@ -34,63 +50,260 @@ var (
 		Version: runoncedurationoverridev1.GroupVersion,
 		Kind:    runoncedurationoverridev1.RunOnceDurationOverrideKind,
 	}
+
+	// conditionPrefixes are the condition prefixes of the sub-controllers, in
+	// the order their conditions are aggregated in.
+	conditionPrefixes = []string{
+		ConfigurationConditionPrefix,
+		CertManagementConditionPrefix,
+		WorkloadRolloutConditionPrefix,
+		WebhookRegistrationConditionPrefix,
+	}
 )
 
-func NewTargetConfigController(
+// NewTargetConfigControllers returns the controllers that reconcile the operand,
+// one for each of its areas: the configuration, the serving cert, the rollout of
+// the webhook server and the registration of the webhook. Each controller has its
+// own queue and informers, and reports its own conditions, so that a failure in
+// one area does not hold up the others.
+func NewTargetConfigControllers(
 	operatorClient *operatorclient.RunOnceDurationOverrideClient,
 	kubeClient kubernetes.Interface,
+	dynamicClient dynamic.Interface,
 	runtimeContext operatorruntime.OperandContext,
 	informerFactory informers.SharedInformerFactory,
 	operatorInformerFactory operatorinformers.SharedInformerFactory,
 	recorder events.Recorder,
-) factory.Controller {
+	objectRecorder record.EventRecorder,
+) []factory.Controller {
 	// setup operand asset
 	operandAsset := asset.New(runtimeContext)
 
-	deployInterface := deploy.NewDaemonSetInstall(
+	daemonSetInstall := deploy.NewDaemonSetInstall(
 		informerFactory.Apps().V1().DaemonSets().Lister(),
 		runtimeContext,
 		operandAsset,
 		kubeClient,
 		recorder,
 	)
+	deploymentInstall := deploy.NewDeploymentInstall(
+		informerFactory.Apps().V1().Deployments().Lister(),
+		runtimeContext,
+		operandAsset,
+		kubeClient,
+		recorder,
+	)
+
+	remover := NewRemovalHandler(kubeClient, recorder, operandAsset).WithDynamicClient(dynamicClient)
+	monitoringHandler := NewMonitoringHandler(kubeClient, dynamicClient, recorder, operandAsset)
+	secretLister := informerFactory.Core().V1().Secrets().Lister()
+	configMapLister := informerFactory.Core().V1().ConfigMaps().Lister()
 
-	c := &runOnceDurationOverrideController{
-		lister:         operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides().Lister(),
-		operatorClient: operatorClient,
-		operandContext: runtimeContext,
-		handlers: []Handler{
+	certGenerationHandler := NewCertGenerationHandler(kubeClient, recorder, secretLister, configMapLister, operandAsset)
+	serviceHandler := NewServiceHandler(kubeClient, recorder, operandAsset)
+	userProvidedCertHandler := NewUserProvidedCertHandler(secretLister, configMapLister, operandAsset)
+	// setWebhookHandlers sets the handler chains of the Webhook deployment mode,
+	// for each workload and cert source. webhookHandlers returns the chain for
+	// the given workload, with the handlers that provide the serving cert.
+	setWebhookHandlers := func(c *runOnceDurationOverrideController, webhookHandlers func(deployInterface deploy.Interface, certHandlers ...Handler) []Handler) {
+		c.handlers = map[runoncedurationoverridev1.WorkloadType][]Handler{
+			runoncedurationoverridev1.WorkloadDaemonSet:  webhookHandlers(daemonSetInstall, certGenerationHandler),
+			runoncedurationoverridev1.WorkloadDeployment: webhookHandlers(deploymentInstall, serviceHandler, certGenerationHandler),
+		}
+		// The service-ca operator issues the serving cert for the Service, and
+		// injects its CA bundle into the CA bundle ConfigMap.
+		c.serviceCAHandlers = webhookHandlers(deploymentInstall,
+			serviceHandler,
+			NewServiceCertSecretHandler(kubeClient, secretLister, operandAsset),
+			NewServiceCAConfigMapHandler(kubeClient, recorder, configMapLister, operandAsset),
+		)
+		// The serving cert and CA bundle are provided by the user.
+		c.userProvidedHandlers = map[runoncedurationoverridev1.WorkloadType][]Handler{
+			runoncedurationoverridev1.WorkloadDaemonSet:  webhookHandlers(daemonSetInstall, userProvidedCertHandler),
+			runoncedurationoverridev1.WorkloadDeployment: webhookHandlers(deploymentInstall, serviceHandler, userProvidedCertHandler),
+		}
+	}
+
+	// The spec is validated once for all the controllers, each reports an invalid
+	// spec under its own prefix.
+	validator := NewSpecValidator()
+	admissionPolicies := NewAdmissionPolicyDiscovery(kubeClient.Discovery())
+	newController := func(conditionPrefix string) *runOnceDurationOverrideController {
+		return &runOnceDurationOverrideController{
+			validator:         validator,
+			lister:            operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides().Lister(),
+			operatorClient:    operatorClient,
+			operandContext:    runtimeContext,
+			objectRecorder:    objectRecorder,
+			admissionPolicies: admissionPolicies,
+			conditionPrefix:   conditionPrefix,
+		}
+	}
+
+	// The configuration controller also owns the deployment mode, the management
+	// state and the finalizer.
+	configuration := newController(ConfigurationConditionPrefix)
+	configuration.finalizer = NewFinalizer(operatorClient.OperatorClient, kubeClient, remover)
+	setWebhookHandlers(configuration, func(deployInterface deploy.Interface, certHandlers ...Handler) []Handler {
+		return []Handler{
+			NewWebhookModeHandler(remover),
+			NewConfigurationHandler(kubeClient, recorder, configMapLister, operandAsset),
+			monitoringHandler,
+		}
+	})
+	configuration.removedHandlers = []Handler{
+		remover,
+	}
+	configuration.admissionPolicyHandlers = []Handler{
+		NewAdmissionPolicyHandler(kubeClient, dynamicClient, recorder, operandAsset, remover, admissionPolicies),
+		monitoringHandler,
+	}
+
+	certManagement := newController(CertManagementConditionPrefix)
+	setWebhookHandlers(certManagement, func(deployInterface deploy.Interface, certHandlers ...Handler) []Handler {
+		handlers := []Handler{
+			NewCertSourceSwitchHandler(remover),
+		}
+		handlers = append(handlers, certHandlers...)
+
+		return append(handlers,
+			NewCertReadyHandler(kubeClient, secretLister, configMapLister, operandAsset),
+		)
+	})
+
+	// The workload is rolled out with the configuration and serving cert hashes
+	// the other controllers recorded in status, a change of either rolls it out
+	// again. It does not wait for them, the pods start once the ConfigMap and
+	// Secret they mount exist.
+	workloadRollout := newController(WorkloadRolloutConditionPrefix)
+	setWebhookHandlers(workloadRollout, func(deployInterface deploy.Interface, certHandlers ...Handler) []Handler {
+		return []Handler{
 			NewAvailabilityHandler(operandAsset, deployInterface),
-			NewValidationHandler(),
-			NewConfigurationHandler(kubeClient, recorder, informerFactory.Core().V1().ConfigMaps().Lister(), operandAsset),
-			NewCertGenerationHandler(kubeClient, recorder, informerFactory.Core().V1().Secrets().Lister(), informerFactory.Core().V1().ConfigMaps().Lister(), operandAsset),
-			NewCertReadyHandler(kubeClient, informerFactory.Core().V1().Secrets().Lister(), informerFactory.Core().V1().ConfigMaps().Lister()),
 			NewDaemonSetHandler(kubeClient, recorder, operandAsset, deployInterface),
 			NewDeploymentReadyHandler(deployInterface),
-			NewWebhookConfigurationHandlerHandler(kubeClient, recorder, informerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Lister(), operandAsset),
 			NewAvailabilityHandler(operandAsset, deployInterface),
-		},
+		}
+	})
+	// Unmanaged: leave the operand alone, only keep reporting its status.
+	workloadRollout.unmanagedHandlers = map[runoncedurationoverridev1.WorkloadType][]Handler{
+		runoncedurationoverridev1.WorkloadDaemonSet:  {NewAvailabilityHandler(operandAsset, daemonSetInstall)},
+		runoncedurationoverridev1.WorkloadDeployment: {NewAvailabilityHandler(operandAsset, deploymentInstall)},
 	}
 
-	return factory.New().WithFilteredEventsInformers(
-		isOwnedByOperator,
-		operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides().Informer(),
-		informerFactory.Apps().V1().Deployments().Informer(),
-		informerFactory.Apps().V1().DaemonSets().Informer(),
-		informerFactory.Core().V1().Pods().Informer(),
-		informerFactory.Core().V1().ConfigMaps().Informer(),
-		informerFactory.Core().V1().Services().Informer(),
-		informerFactory.Core().V1().Secrets().Informer(),
-		informerFactory.Core().V1().ServiceAccounts().Informer(),
-		informerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Informer(),
-	).WithSync(c.sync).ToController(ControllerName, recorder)
+	// The staged cert rotation and the workload switch wait for the API server to
+	// be handed the CA bundle and the workload, they run once the webhook is
+	// registered. A serving cert that is not usable does not stop the chain, the
+	// webhook keeps the CA bundle last applied.
+	webhookRegistration := newController(WebhookRegistrationConditionPrefix)
+	setWebhookHandlers(webhookRegistration, func(deployInterface deploy.Interface, certHandlers ...Handler) []Handler {
+		return []Handler{
+			NewCertReadyHandler(kubeClient, secretLister, configMapLister, operandAsset).WithOptionalBundle(),
+			NewWebhookConfigurationHandlerHandler(kubeClient, recorder, informerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Lister(), operandAsset).WithWorkload(deployInterface),
+			NewDeploymentReadyHandler(deployInterface),
+			NewCertRotationHandler(kubeClient, recorder, operandAsset, deployInterface),
+			NewWorkloadSwitchHandler(remover),
+		}
+	})
+
+	crInformer := operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides().Informer()
+	// The Secret and ConfigMap of spec.userProvidedCert are not owned by the
+	// operator, the controllers that read them also match them by name.
+	isOwnedOrUserProvidedCert := isOwnedByOperatorOrUserProvidedCert(operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides().Lister(), runtimeContext.WebhookNamespace())
+	return []factory.Controller{
+		factory.New().WithFilteredEventsInformers(
+			isOwnedByOperator,
+			crInformer,
+			informerFactory.Core().V1().ConfigMaps().Informer(),
+		).WithSync(configuration.sync).ToController(ControllerName+"-configuration", recorder),
+		factory.New().WithFilteredEventsInformers(
+			isOwnedOrUserProvidedCert,
+			crInformer,
+			informerFactory.Core().V1().ConfigMaps().Informer(),
+			informerFactory.Core().V1().Services().Informer(),
+			informerFactory.Core().V1().Secrets().Informer(),
+		).WithSync(certManagement.sync).ToController(ControllerName+"-cert-management", recorder),
+		factory.New().WithFilteredEventsInformers(
+			isOwnedOrUserProvidedCert,
+			crInformer,
+			informerFactory.Apps().V1().Deployments().Informer(),
+			informerFactory.Apps().V1().DaemonSets().Informer(),
+			informerFactory.Core().V1().Pods().Informer(),
+			informerFactory.Core().V1().ConfigMaps().Informer(),
+			informerFactory.Core().V1().Secrets().Informer(),
+			informerFactory.Core().V1().ServiceAccounts().Informer(),
+		).WithSync(workloadRollout.sync).ToController(ControllerName+"-workload-rollout", recorder),
+		factory.New().WithFilteredEventsInformers(
+			isOwnedOrUserProvidedCert,
+			crInformer,
+			informerFactory.Apps().V1().Deployments().Informer(),
+			informerFactory.Apps().V1().DaemonSets().Informer(),
+			informerFactory.Core().V1().ConfigMaps().Informer(),
+			informerFactory.Core().V1().Secrets().Informer(),
+			informerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Informer(),
+		).WithSync(webhookRegistration.sync).ToController(ControllerName+"-webhook-registration", recorder),
+	}
 }
 
 type runOnceDurationOverrideController struct {
 	lister         runoncedurationoverridev1listers.RunOnceDurationOverrideLister
 	operatorClient *operatorclient.RunOnceDurationOverrideClient
-	handlers       []Handler
 	operandContext operatorruntime.OperandContext
+	// objectRecorder records Events on the RunOnceDurationOverride object.
+	objectRecorder record.EventRecorder
+	// finalizer is only set on the controller that removes the cluster scoped
+	// resources on deletion.
+	finalizer *finalizer
+	// conditionPrefix is prepended to the conditions the controller reports.
+	conditionPrefix string
+	// validator is shared by the controllers, the handler chains only run for
+	// a valid spec.
+	validator *specValidator
+
+	// handlers, userProvidedHandlers and unmanagedHandlers are keyed by the
+	// workload that runs the webhook server.
+	handlers                map[runoncedurationoverridev1.WorkloadType][]Handler
+	serviceCAHandlers       []Handler
+	userProvidedHandlers    map[runoncedurationoverridev1.WorkloadType][]Handler
+	unmanagedHandlers       map[runoncedurationoverridev1.WorkloadType][]Handler
+	removedHandlers         []Handler
+	admissionPolicyHandlers []Handler
+
+	// admissionPolicies is shared by the controllers.
+	admissionPolicies *admissionPolicyDiscovery
+}
+
+// handlersFor returns the handler chain that reconciles the given spec.
+// Force is treated the same as Managed.
+// The AdmissionPolicy mode falls back to the Webhook mode only when the API
+// server definitely does not serve the MutatingAdmissionPolicy API, a failed
+// discovery is returned so that the request is requeued.
+func (c *runOnceDurationOverrideController) handlersFor(spec *runoncedurationoverridev1.RunOnceDurationOverrideSpec) ([]Handler, error) {
+	switch spec.ManagementState {
+	case operatorv1.Unmanaged:
+		return c.unmanagedHandlers[spec.GetWorkload()], nil
+	case operatorv1.Removed:
+		return c.removedHandlers, nil
+	}
+
+	if spec.GetDeploymentMode() == runoncedurationoverridev1.DeploymentModeAdmissionPolicy {
+		version, err := c.admissionPolicies.ServedVersion()
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover the MutatingAdmissionPolicy API - %s", err.Error())
+		}
+		if version != "" {
+			return c.admissionPolicyHandlers, nil
+		}
+	}
+
+	if spec.GetCertSource() == runoncedurationoverridev1.CertSourceServiceCA && spec.GetWorkload() == runoncedurationoverridev1.WorkloadDeployment {
+		return c.serviceCAHandlers, nil
+	}
+
+	if spec.GetCertSource() == runoncedurationoverridev1.CertSourceUserProvided && spec.UserProvidedCert != nil {
+		return c.userProvidedHandlers[spec.GetWorkload()], nil
+	}
+
+	return c.handlers[spec.GetWorkload()], nil
 }
 
 func (c *runOnceDurationOverrideController) sync(ctx context.Context, syncCtx factory.SyncContext) error {
@@ -111,15 +324,52 @@ func (c *runOnceDurationOverrideController) sync(ctx context.Context, syncCtx fa
 	copy := original.DeepCopy()
 	copy.SetGroupVersionKind(RunOnceDurationOverrideGVK)
 
-	reconcileContext := NewReconcileRequestContext(c.operandContext)
+	if copy.DeletionTimestamp != nil {
+		if c.finalizer == nil {
+			return nil
+		}
+
+		klog.V(2).Infof("key=%s object is being deleted, removing cluster scoped resources", operatorclient.OperatorConfigName)
+		return c.finalizer.Finalize(ctx, copy, c.objectRecorder)
+	}
+
+	if c.finalizer != nil {
+		if err := c.finalizer.Ensure(ctx, copy); err != nil {
+			return err
+		}
+	}
+
+	// The handler chain sees the conditions of the area of this controller
+	// without their prefix. Progressing is left out, so that it is only reported
+	// by the handlers that set it in this run.
+	areaOriginal := areaStatus(&original.Status, c.conditionPrefix)
+	copy.Status = *areaOriginal.DeepCopy()
+	v1helpers.RemoveOperatorCondition(&copy.Status.Conditions, operatorv1.OperatorStatusTypeProgressing)
+
+	reconcileContext := NewReconcileRequestContext(c.operandContext).WithRecorder(c.objectRecorder)
 	modified := copy
 	var current *runoncedurationoverridev1.RunOnceDurationOverride
 	var err error
 	var requeueRequested bool
-	for _, handler := range c.handlers {
+	managementState := copy.Spec.ManagementState
+	klog.V(4).Infof("key=%s managementState=%q", operatorclient.OperatorConfigName, managementState)
+	current = copy
+	handlers, err := c.handlersFor(&copy.Spec)
+	if err != nil {
+		klog.Errorf("[reconciler] key=%s %s", operatorclient.OperatorConfigName, err.Error())
+		return err
+	}
+	if len(handlers) > 0 && managementState != operatorv1.Unmanaged && managementState != operatorv1.Removed {
+		if err = c.validator.Validate(reconcileContext, copy); err != nil {
+			handlers = nil
+		}
+	}
+	for _, handler := range handlers {
 		var result controllerreconciler.Result
 		var handlerErr error
+		start := time.Now()
 		current, result, handlerErr = handler.Handle(reconcileContext, modified)
+		metrics.ObserveHandler(handlerName(handler), time.Since(start), GetReason(handlerErr))
 
 		if handlerErr != nil {
 			err = handlerErr
@@ -149,7 +399,8 @@ func (c *runOnceDurationOverrideController) sync(ctx context.Context, syncCtx fa
 			Reason:  reason,
 			Message: err.Error(),
 		})
-	} else {
+	} else if managementState != operatorv1.Unmanaged && managementState != operatorv1.Removed {
+		// The Unmanaged and Removed handler chains report their own conditions.
 		v1helpers.SetOperatorCondition(&statusToApply.OperatorStatus.Conditions, operatorv1.OperatorCondition{
 			Type:   runoncedurationoverridev1.InstallReadinessFailure,
 			Status: operatorv1.ConditionFalse,
@@ -159,11 +410,24 @@ func (c *runOnceDurationOverrideController) sync(ctx context.Context, syncCtx fa
 			Status: operatorv1.ConditionTrue,
 		})
 	}
+	setStandardConditions(areaOriginal, statusToApply, err, time.Now())
+	keepTransitionTime(areaOriginal, statusToApply, operatorv1.OperatorStatusTypeProgressing)
+
+	prefixAreaConditions(areaOriginal, c.conditionPrefix)
+	prefixAreaConditions(statusToApply, c.conditionPrefix)
 
-	// Build status update function that applies the complete status including custom fields
+	// The other controllers of the operator write to the same status, only what
+	// this sync changed is applied to the latest status so that what they wrote
+	// is not overwritten with the stale status read here. The operator
+	// conditions are then aggregated from the conditions of all areas.
 	statusUpdateFuncs := []operatorclient.UpdateRunOnceDurationOverrideStatusFunc{
 		func(status *runoncedurationoverridev1.RunOnceDurationOverrideStatus) error {
-			*status = *statusToApply
+			previous := status.DeepCopy()
+			if err := mergeStatus(status, areaOriginal, statusToApply); err != nil {
+				return err
+			}
+			setOperatorConditions(status, conditionPrefixes)
+			recordStatusMetrics(previous, status)
 			return nil
 		},
 	}
@@ -190,3 +454,122 @@ func (c *runOnceDurationOverrideController) sync(ctx context.Context, syncCtx fa
 
 	return nil
 }
+
+// handlerName returns the type name of the given handler, used as the handler
+// label of the reconcile metrics.
+func handlerName(handler Handler) string {
+	name := fmt.Sprintf("%T", handler)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// recordStatusMetrics records the metrics derived from the status written by
+// the handler chain.
+func recordStatusMetrics(original, current *runoncedurationoverridev1.RunOnceDurationOverrideStatus) {
+	hashes := map[string][2]string{
+		"configuration":    {original.Hash.Configuration, current.Hash.Configuration},
+		"servingCert":      {original.Hash.ServingCert, current.Hash.ServingCert},
+		"observedConfig":   {original.Hash.ObservedConfig, current.Hash.ObservedConfig},
+		"operandPlacement": {original.Hash.OperandPlacement, current.Hash.OperandPlacement},
+	}
+	for hash, values := range hashes {
+		if values[0] != "" && values[1] != "" && values[0] != values[1] {
+			metrics.ConfigHashChangesTotal.WithLabelValues(hash).Inc()
+		}
+	}
+
+	metrics.SetServingCertNotAfter(current.Certificates.Serving.NotAfter.Time)
+}
+
+// mergeStatus applies the status the handler chain computed from original onto
+// latest. Only the fields the handler chain changed are applied. Conditions and
+// generations are merged by key: an item is only set or removed if the handler
+// chain changed it.
+func mergeStatus(latest, original, desired *runoncedurationoverridev1.RunOnceDurationOverrideStatus) error {
+	conditions := latest.Conditions
+	for _, condition := range desired.Conditions {
+		previous := v1helpers.FindOperatorCondition(original.Conditions, condition.Type)
+		if previous == nil || !equality.Semantic.DeepEqual(*previous, condition) {
+			v1helpers.SetOperatorCondition(&conditions, condition)
+		}
+	}
+	for _, condition := range original.Conditions {
+		if v1helpers.FindOperatorCondition(desired.Conditions, condition.Type) == nil {
+			v1helpers.RemoveOperatorCondition(&conditions, condition.Type)
+		}
+	}
+
+	generations := latest.Generations
+	for _, generation := range desired.Generations {
+		previous := findGeneration(original.Generations, generation)
+		if previous == nil || !equality.Semantic.DeepEqual(*previous, generation) {
+			resourcemerge.SetGeneration(&generations, generation)
+		}
+	}
+	for _, generation := range original.Generations {
+		if findGeneration(desired.Generations, generation) == nil {
+			generations = removeGeneration(generations, generation)
+		}
+	}
+
+	merged, err := mergeFields(latest, original, desired)
+	if err != nil {
+		return err
+	}
+
+	*latest = *merged
+	latest.Conditions = conditions
+	latest.Generations = generations
+	return nil
+}
+
+// mergeFields applies the fields that changed from original to desired onto
+// latest, leaving out the conditions and generations.
+func mergeFields(latest, original, desired *runoncedurationoverridev1.RunOnceDurationOverrideStatus) (*runoncedurationoverridev1.RunOnceDurationOverrideStatus, error) {
+	documents := make([][]byte, 0, 3)
+	for _, status := range []*runoncedurationoverridev1.RunOnceDurationOverrideStatus{latest, original, desired} {
+		status = status.DeepCopy()
+		status.Conditions, status.Generations = nil, nil
+
+		document, err := json.Marshal(status)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+
+	patch, err := strategicpatch.CreateTwoWayMergePatch(documents[1], documents[2], runoncedurationoverridev1.RunOnceDurationOverrideStatus{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the status patch - %s", err.Error())
+	}
+
+	document, err := strategicpatch.StrategicMergePatch(documents[0], patch, runoncedurationoverridev1.RunOnceDurationOverrideStatus{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply the status patch - %s", err.Error())
+	}
+
+	merged := &runoncedurationoverridev1.RunOnceDurationOverrideStatus{}
+	if err := json.Unmarshal(document, merged); err != nil {
+		return nil, err
+	}
+
+	return merged, nil
+}
+
+// findGeneration returns the generation of the same resource as the given one.
+func findGeneration(generations []operatorv1.GenerationStatus, generation operatorv1.GenerationStatus) *operatorv1.GenerationStatus {
+	return resourcemerge.GenerationFor(generations, schema.GroupResource{Group: generation.Group, Resource: generation.Resource}, generation.Namespace, generation.Name)
+}
+
+// removeGeneration returns the generations without the one of the same
+// resource as the given one.
+func removeGeneration(generations []operatorv1.GenerationStatus, generation operatorv1.GenerationStatus) []operatorv1.GenerationStatus {
+	out := []operatorv1.GenerationStatus{}
+	for _, existing := range generations {
+		if existing.Group == generation.Group && existing.Resource == generation.Resource && existing.Namespace == generation.Namespace && existing.Name == generation.Name {
+			continue
+		}
+		out = append(out, existing)
+	}
+
+	return out
+}
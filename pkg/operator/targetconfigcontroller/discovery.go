@@ -0,0 +1,98 @@
+package targetconfigcontroller
+
+import (
+	"sync"
+	"time"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// AdmissionPolicyDiscoveryInterval is how long the result of the discovery
+	// of the MutatingAdmissionPolicy API is used before it is refreshed.
+	AdmissionPolicyDiscoveryInterval = 5 * time.Minute
+)
+
+var (
+	// admissionPolicyVersions are the versions of the MutatingAdmissionPolicy
+	// API the AdmissionPolicy deployment mode can use, the preferred first.
+	admissionPolicyVersions = []schema.GroupVersion{
+		{Group: admissionregistrationv1beta1.GroupName, Version: "v1"},
+		admissionregistrationv1beta1.SchemeGroupVersion,
+	}
+)
+
+func NewAdmissionPolicyDiscovery(client discovery.DiscoveryInterface) *admissionPolicyDiscovery {
+	return &admissionPolicyDiscovery{
+		client:   client,
+		interval: AdmissionPolicyDiscoveryInterval,
+		now:      time.Now,
+	}
+}
+
+// admissionPolicyDiscovery finds the version of the MutatingAdmissionPolicy API
+// the API server serves. It is shared by the controllers, the result is cached
+// and refreshed on an interval, so that a sync does not make a discovery call.
+type admissionPolicyDiscovery struct {
+	client   discovery.DiscoveryInterface
+	interval time.Duration
+	now      func() time.Time
+
+	lock       sync.Mutex
+	discovered bool
+	checkedAt  time.Time
+	version    string
+}
+
+// ServedVersion returns the preferred version of the MutatingAdmissionPolicy
+// API that is served, or an empty string if the API server definitely serves
+// none. An error is returned when nothing has been discovered yet and the
+// discovery fails, the caller is expected to requeue. A failed refresh keeps
+// the version discovered last.
+func (d *admissionPolicyDiscovery) ServedVersion() (string, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.discovered && d.now().Sub(d.checkedAt) < d.interval {
+		return d.version, nil
+	}
+
+	version, err := d.discover()
+	if err != nil {
+		if !d.discovered {
+			return "", err
+		}
+
+		klog.Warningf("[reconciler] failed to refresh the discovery of the MutatingAdmissionPolicy API, using version=%q - %s", d.version, err.Error())
+		return d.version, nil
+	}
+
+	d.discovered = true
+	d.checkedAt = d.now()
+	d.version = version
+	return version, nil
+}
+
+func (d *admissionPolicyDiscovery) discover() (string, error) {
+	for _, groupVersion := range admissionPolicyVersions {
+		resources, err := d.client.ServerResourcesForGroupVersion(groupVersion.String())
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return "", err
+		}
+
+		for _, resource := range resources.APIResources {
+			if resource.Name == "mutatingadmissionpolicies" {
+				return groupVersion.Version, nil
+			}
+		}
+	}
+
+	return "", nil
+}
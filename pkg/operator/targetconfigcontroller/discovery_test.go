@@ -0,0 +1,93 @@
+package targetconfigcontroller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+)
+
+func newTestAdmissionPolicyDiscovery(versions ...string) (*admissionPolicyDiscovery, *kubefake.Clientset, *time.Time) {
+	fakeKubeClient := kubefake.NewClientset()
+	for _, version := range versions {
+		fakeKubeClient.Resources = append(fakeKubeClient.Resources, &metav1.APIResourceList{
+			GroupVersion: "admissionregistration.k8s.io/" + version,
+			APIResources: []metav1.APIResource{{Name: "mutatingadmissionpolicies"}, {Name: "mutatingadmissionpolicybindings"}},
+		})
+	}
+
+	now := time.Now()
+	d := NewAdmissionPolicyDiscovery(fakeKubeClient.Discovery())
+	d.now = func() time.Time { return now }
+	return d, fakeKubeClient, &now
+}
+
+func failDiscovery(fakeKubeClient *kubefake.Clientset) {
+	fakeKubeClient.PrependReactor("get", "resource", func(action kubetesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("simulated discovery error")
+	})
+}
+
+func TestAdmissionPolicyDiscoveryServedVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		expected string
+	}{
+		{name: "NotServed", expected: ""},
+		{name: "V1beta1", versions: []string{"v1beta1"}, expected: "v1beta1"},
+		{name: "V1", versions: []string{"v1"}, expected: "v1"},
+		{name: "V1Preferred", versions: []string{"v1beta1", "v1"}, expected: "v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _, _ := newTestAdmissionPolicyDiscovery(tt.versions...)
+
+			version, err := d.ServedVersion()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != tt.expected {
+				t.Errorf("expected version %q, got %q", tt.expected, version)
+			}
+		})
+	}
+}
+
+func TestAdmissionPolicyDiscoveryError(t *testing.T) {
+	d, fakeKubeClient, _ := newTestAdmissionPolicyDiscovery("v1beta1")
+	failDiscovery(fakeKubeClient)
+
+	if _, err := d.ServedVersion(); err == nil {
+		t.Fatalf("expected the discovery error to be returned, not a fallback")
+	}
+}
+
+func TestAdmissionPolicyDiscoveryCache(t *testing.T) {
+	d, fakeKubeClient, now := newTestAdmissionPolicyDiscovery("v1beta1")
+
+	if version, err := d.ServedVersion(); err != nil || version != "v1beta1" {
+		t.Fatalf("expected version v1beta1, got %q err=%v", version, err)
+	}
+
+	// Within the interval the result is served from the cache.
+	fakeKubeClient.ClearActions()
+	if version, err := d.ServedVersion(); err != nil || version != "v1beta1" {
+		t.Fatalf("expected version v1beta1, got %q err=%v", version, err)
+	}
+	if actions := fakeKubeClient.Actions(); len(actions) != 0 {
+		t.Errorf("expected no discovery call within the interval, got %d", len(actions))
+	}
+
+	// A failed refresh keeps the version discovered last.
+	failDiscovery(fakeKubeClient)
+	*now = now.Add(AdmissionPolicyDiscoveryInterval)
+	if version, err := d.ServedVersion(); err != nil || version != "v1beta1" {
+		t.Errorf("expected the last discovered version v1beta1, got %q err=%v", version, err)
+	}
+}
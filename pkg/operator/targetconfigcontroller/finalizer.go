@@ -0,0 +1,113 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"fmt"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	operatorconfigclientv1 "github.com/openshift/run-once-duration-override-operator/pkg/generated/clientset/versioned/typed/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/operator/operatorclient"
+)
+
+func NewFinalizer(client operatorconfigclientv1.RunOnceDurationOverridesGetter, kubeClient kubernetes.Interface, remover *removalHandler) *finalizer {
+	return &finalizer{
+		client:     client,
+		kubeClient: kubeClient,
+		remover:    remover,
+	}
+}
+
+// finalizer holds the deletion of the RunOnceDurationOverride until the cluster
+// scoped resources of the operand are removed. Namespaced resources are garbage
+// collected through their owner references, but a MutatingWebhookConfiguration
+// left behind points at a webhook server that is gone and blocks pod admission.
+type finalizer struct {
+	client     operatorconfigclientv1.RunOnceDurationOverridesGetter
+	kubeClient kubernetes.Interface
+	remover    *removalHandler
+}
+
+// Ensure adds the finalizer to the given object if it is missing.
+func (f *finalizer) Ensure(ctx context.Context, original *appsv1.RunOnceDurationOverride) error {
+	if hasFinalizer(original) {
+		return nil
+	}
+
+	copy := original.DeepCopy()
+	copy.Finalizers = append(copy.Finalizers, operatorclient.OperatorFinalizer)
+	if _, err := f.client.RunOnceDurationOverrides().Update(ctx, copy, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to add finalizer %s - %s", operatorclient.OperatorFinalizer, err.Error())
+	}
+
+	klog.V(2).Infof("key=%s added finalizer %s", original.Name, operatorclient.OperatorFinalizer)
+	return nil
+}
+
+// Finalize removes the cluster scoped resources of the operand in order, and
+// then the finalizer. The webhook configuration goes first, and the rest is
+// only removed once the API server no longer has it, so that pod admission
+// never calls a webhook server that lost its RBAC. The webhook server of an
+// Unmanaged operand is garbage collected with the object, so its webhook
+// configuration is removed too, but its RBAC is left alone.
+func (f *finalizer) Finalize(ctx context.Context, original *appsv1.RunOnceDurationOverride, recorder record.EventRecorder) error {
+	if !hasFinalizer(original) {
+		return nil
+	}
+
+	if err := f.remover.RemoveAdmissionPolicy(); err != nil {
+		return err
+	}
+
+	if err := f.remover.RemoveWebhookConfiguration(); err != nil {
+		return err
+	}
+
+	name := f.remover.asset.NewMutatingWebhookConfiguration().Name()
+	_, err := f.kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		return fmt.Errorf("waiting for MutatingWebhookConfiguration %s to be removed", name)
+	case !k8serrors.IsNotFound(err):
+		return err
+	}
+
+	if original.Spec.ManagementState != operatorv1.Unmanaged {
+		if err := f.remover.RemoveClusterRBAC(); err != nil {
+			return err
+		}
+
+		recorder.Event(original, corev1.EventTypeNormal, "ClusterResourcesRemoved", "Removed the cluster scoped resources of the operand")
+	}
+
+	copy := original.DeepCopy()
+	copy.Finalizers = nil
+	for _, finalizer := range original.Finalizers {
+		if finalizer != operatorclient.OperatorFinalizer {
+			copy.Finalizers = append(copy.Finalizers, finalizer)
+		}
+	}
+	if _, err := f.client.RunOnceDurationOverrides().Update(ctx, copy, metav1.UpdateOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to remove finalizer %s - %s", operatorclient.OperatorFinalizer, err.Error())
+	}
+
+	klog.V(2).Infof("key=%s removed finalizer %s", original.Name, operatorclient.OperatorFinalizer)
+	return nil
+}
+
+func hasFinalizer(object metav1.Object) bool {
+	for _, finalizer := range object.GetFinalizers() {
+		if finalizer == operatorclient.OperatorFinalizer {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,211 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+	fakeclientset "github.com/openshift/run-once-duration-override-operator/pkg/generated/clientset/versioned/fake"
+	operatorinformers "github.com/openshift/run-once-duration-override-operator/pkg/generated/informers/externalversions"
+	"github.com/openshift/run-once-duration-override-operator/pkg/operator/operatorclient"
+)
+
+func TestFinalizerEnsure(t *testing.T) {
+	rodoo := createTestRodoo(3600, nil)
+	fakeOperatorClient := fakeclientset.NewSimpleClientset(rodoo)
+	operandAsset := asset.New(createTestOperandContext())
+	remover := NewRemovalHandler(kubefake.NewClientset(), events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset)
+
+	f := NewFinalizer(fakeOperatorClient.RunOnceDurationOverrideV1(), kubefake.NewClientset(), remover)
+
+	ctx := context.TODO()
+	if err := f.Ensure(ctx, rodoo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := fakeOperatorClient.RunOnceDurationOverrideV1().RunOnceDurationOverrides().Get(ctx, rodoo.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasFinalizer(updated) {
+		t.Fatalf("expected finalizer %s, got %v", operatorclient.OperatorFinalizer, updated.Finalizers)
+	}
+
+	// The finalizer is only added once.
+	fakeOperatorClient.ClearActions()
+	if err := f.Ensure(ctx, updated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actions := fakeOperatorClient.Actions(); len(actions) != 0 {
+		t.Errorf("expected no update, got %v", actions)
+	}
+}
+
+func TestFinalizerFinalize(t *testing.T) {
+	tests := []struct {
+		name            string
+		managementState operatorv1.ManagementState
+		webhookPending  bool
+		wantRemoved     bool
+		wantRBACRemoved bool
+		wantFinalizer   bool
+	}{
+		{
+			name:            "Managed",
+			managementState: operatorv1.Managed,
+			wantRemoved:     true,
+			wantRBACRemoved: true,
+		},
+		{
+			name:            "WebhookRemovalPending",
+			managementState: operatorv1.Managed,
+			webhookPending:  true,
+			wantFinalizer:   true,
+		},
+		{
+			name:            "Unmanaged",
+			managementState: operatorv1.Unmanaged,
+			wantRemoved:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operandAsset := asset.New(createTestOperandContext())
+
+			objects := []runtime.Object{operandAsset.NewMutatingWebhookConfiguration().New()}
+			for _, item := range operandAsset.RBAC().New() {
+				objects = append(objects, item.Object)
+			}
+			fakeKubeClient := kubefake.NewClientset(objects...)
+			if tt.webhookPending {
+				// The API server has not removed the webhook configuration yet.
+				fakeKubeClient.PrependReactor("delete", "mutatingwebhookconfigurations", func(action kubetesting.Action) (bool, runtime.Object, error) {
+					return true, nil, nil
+				})
+			}
+
+			rodoo := createTestRodoo(3600, withManagementState(tt.managementState))
+			now := metav1.Now()
+			rodoo.DeletionTimestamp = &now
+			rodoo.Finalizers = []string{operatorclient.OperatorFinalizer}
+			fakeOperatorClient := fakeclientset.NewSimpleClientset(rodoo)
+
+			remover := NewRemovalHandler(fakeKubeClient, events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset)
+			f := NewFinalizer(fakeOperatorClient.RunOnceDurationOverrideV1(), fakeKubeClient, remover)
+
+			ctx := context.TODO()
+			err := f.Finalize(ctx, rodoo, record.NewFakeRecorder(10))
+			if tt.webhookPending != (err != nil) {
+				t.Fatalf("expected error=%t, got %v", tt.webhookPending, err)
+			}
+
+			name := operandAsset.NewMutatingWebhookConfiguration().Name()
+			_, err = fakeKubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, name, metav1.GetOptions{})
+			if tt.wantRemoved != k8serrors.IsNotFound(err) {
+				t.Errorf("expected MutatingWebhookConfiguration removed=%t, got err=%v", tt.wantRemoved, err)
+			}
+
+			var webhookDeleted bool
+			for _, action := range fakeKubeClient.Actions() {
+				if !action.Matches("delete", "mutatingwebhookconfigurations") && !action.Matches("delete", "clusterroles") &&
+					!action.Matches("delete", "clusterrolebindings") && !action.Matches("delete", "rolebindings") {
+					continue
+				}
+				if action.GetResource().Resource == "mutatingwebhookconfigurations" {
+					webhookDeleted = true
+					continue
+				}
+				if !webhookDeleted {
+					t.Errorf("expected the MutatingWebhookConfiguration to be removed before %s", action.GetResource().Resource)
+				}
+				if namespace := action.GetNamespace(); namespace != "" && namespace != "kube-system" {
+					t.Errorf("expected only cluster scoped RBAC and the kube-system RoleBinding to be removed, got %s in %s", action.GetResource().Resource, namespace)
+				}
+			}
+
+			for _, item := range operandAsset.RBAC().New() {
+				var err error
+				switch item.Resource {
+				case "clusterroles":
+					_, err = fakeKubeClient.RbacV1().ClusterRoles().Get(ctx, item.Object.GetName(), metav1.GetOptions{})
+				case "clusterrolebindings":
+					_, err = fakeKubeClient.RbacV1().ClusterRoleBindings().Get(ctx, item.Object.GetName(), metav1.GetOptions{})
+				case "rolebindings":
+					_, err = fakeKubeClient.RbacV1().RoleBindings(item.Object.GetNamespace()).Get(ctx, item.Object.GetName(), metav1.GetOptions{})
+				default:
+					continue
+				}
+
+				wantRemoved := tt.wantRBACRemoved && item.Object.GetNamespace() != operandAsset.Values().Namespace
+				if wantRemoved != k8serrors.IsNotFound(err) {
+					t.Errorf("expected %s %s removed=%t, got err=%v", item.Resource, item.Object.GetName(), wantRemoved, err)
+				}
+			}
+
+			updated, err := fakeOperatorClient.RunOnceDurationOverrideV1().RunOnceDurationOverrides().Get(ctx, rodoo.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if hasFinalizer(updated) != tt.wantFinalizer {
+				t.Errorf("expected finalizer=%t, got %v", tt.wantFinalizer, updated.Finalizers)
+			}
+		})
+	}
+}
+
+// TestFinalizerSync checks that sync cleans up instead of reconciling an object
+// that is being deleted.
+func TestFinalizerSync(t *testing.T) {
+	rodoo := createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+		now := metav1.Now()
+		rodoo.DeletionTimestamp = &now
+		rodoo.Finalizers = []string{operatorclient.OperatorFinalizer}
+	})
+	operandAsset := asset.New(createTestOperandContext())
+	fakeKubeClient := kubefake.NewClientset(operandAsset.NewMutatingWebhookConfiguration().New())
+	fakeOperatorClient := fakeclientset.NewSimpleClientset(rodoo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	operatorInformerFactory := operatorinformers.NewSharedInformerFactory(fakeOperatorClient, 0)
+	lister := operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides().Lister()
+	operatorInformerFactory.Start(ctx.Done())
+	operatorInformerFactory.WaitForCacheSync(ctx.Done())
+
+	remover := NewRemovalHandler(fakeKubeClient, events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset)
+	objectRecorder := record.NewFakeRecorder(10)
+	c := &runOnceDurationOverrideController{
+		lister:         lister,
+		objectRecorder: objectRecorder,
+		finalizer:      NewFinalizer(fakeOperatorClient.RunOnceDurationOverrideV1(), fakeKubeClient, remover),
+	}
+
+	if err := c.sync(ctx, &fakeSyncContext{recorder: events.NewLoggingEventRecorder("test", clock.RealClock{})}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := fakeOperatorClient.RunOnceDurationOverrideV1().RunOnceDurationOverrides().Get(ctx, rodoo.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hasFinalizer(updated) {
+		t.Errorf("expected the finalizer to be removed, got %v", updated.Finalizers)
+	}
+	if len(updated.Status.Conditions) != 0 {
+		t.Errorf("expected no status update on an object that is being deleted, got %v", updated.Status.Conditions)
+	}
+	verifyEvents(t, objectRecorder, "ClusterResourcesRemoved")
+}
@@ -1,11 +1,14 @@
 package targetconfigcontroller
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
 	"github.com/openshift/library-go/pkg/controller/factory"
 	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	runoncedurationoverridev1listers "github.com/openshift/run-once-duration-override-operator/pkg/generated/listers/runoncedurationoverride/v1"
 	"github.com/openshift/run-once-duration-override-operator/pkg/operator/operatorclient"
 	"github.com/openshift/run-once-duration-override-operator/pkg/runtime"
 )
@@ -29,6 +32,34 @@ func isOwnedByOperator(obj interface{}) bool {
 
 var _ factory.EventFilterFunc = isOwnedByOperator
 
+// isOwnedByOperatorOrUserProvidedCert returns an event filter that also matches
+// the Secret and ConfigMap of spec.userProvidedCert in the given namespace. They
+// are owned by the user, the operator does not mark them as its own.
+func isOwnedByOperatorOrUserProvidedCert(lister runoncedurationoverridev1listers.RunOnceDurationOverrideLister, namespace string) factory.EventFilterFunc {
+	return func(obj interface{}) bool {
+		if isOwnedByOperator(obj) {
+			return true
+		}
+
+		cro, err := lister.Get(operatorclient.OperatorConfigName)
+		if err != nil || cro.Spec.GetCertSource() != runoncedurationoverridev1.CertSourceUserProvided || cro.Spec.UserProvidedCert == nil {
+			return false
+		}
+
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		switch object := obj.(type) {
+		case *corev1.Secret:
+			return object.Namespace == namespace && object.Name == cro.Spec.UserProvidedCert.SecretName
+		case *corev1.ConfigMap:
+			return object.Namespace == namespace && object.Name == cro.Spec.UserProvidedCert.CAConfigMapName
+		}
+
+		return false
+	}
+}
+
 func getOwnerName(object metav1.Object) string {
 	// We check for annotations and owner references
 	// If both exist, owner references takes precedence.
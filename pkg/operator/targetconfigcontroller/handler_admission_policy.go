@@ -0,0 +1,237 @@
+package targetconfigcontroller
+
+import (
+	gocontext "context"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apis/reference"
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+func NewAdmissionPolicyHandler(client kubernetes.Interface, dynamicClient dynamic.Interface, recorder events.Recorder, asset *asset.Asset, remover *removalHandler, policies *admissionPolicyDiscovery) *admissionPolicyHandler {
+	return &admissionPolicyHandler{
+		client:        client,
+		dynamicClient: dynamicClient,
+		recorder:      recorder,
+		asset:         asset,
+		remover:       remover,
+		policies:      policies,
+	}
+}
+
+// admissionPolicyHandler reconciles the MutatingAdmissionPolicy and its binding
+// in the AdmissionPolicy deployment mode, in place of the webhook handlers.
+// They are applied through the preferred version of the API that is served.
+type admissionPolicyHandler struct {
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	recorder      events.Recorder
+	asset         *asset.Asset
+	remover       *removalHandler
+	policies      *admissionPolicyDiscovery
+}
+
+func (a *admissionPolicyHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
+	current = original
+
+	if original.Status.DeploymentMode != appsv1.DeploymentModeAdmissionPolicy {
+		if err := a.remover.RemoveWebhook(); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.CannotRemoveOperand, err)
+			return
+		}
+
+		current.Status.Resources = appsv1.RunOnceDurationOverrideResources{}
+		current.Status.Hash = appsv1.RunOnceDurationOverrideResourceHash{}
+		current.Status.CertsRotateAt = metav1.Time{}
+		current.Status.Generations = nil
+		current.Status.Workload = ""
+		current.Status.CertSource = ""
+		current.Status.CertRotation = appsv1.CertRotationStatus{}
+		current.Status.Certificates = appsv1.CertificatesStatus{}
+		current.Status.DeploymentMode = appsv1.DeploymentModeAdmissionPolicy
+		klog.V(2).Infof("key=%s switched to deployment mode %s", original.Name, appsv1.DeploymentModeAdmissionPolicy)
+	}
+
+	v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+		Type:   appsv1.DeploymentModeFallback,
+		Status: operatorv1.ConditionFalse,
+		Reason: appsv1.AsExpected,
+	})
+	// The API server applies the policy, there is no workload to roll out.
+	v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+		Type:   operatorv1.OperatorStatusTypeProgressing,
+		Status: operatorv1.ConditionFalse,
+		Reason: appsv1.AsExpected,
+	})
+
+	if original.Spec.RunOnceDurationOverrideConfig.Spec.GetMode() == appsv1.OverrideModeDisabled {
+		if err := a.remover.RemoveAdmissionPolicy(); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+			return
+		}
+
+		current.Status.Resources.MutatingAdmissionPolicyRef = nil
+		current.Status.Resources.MutatingAdmissionPolicyBindingRef = nil
+		return
+	}
+
+	version, err := a.policies.ServedVersion()
+	if err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	ctx := gocontext.TODO()
+	desiredPolicy, desiredBinding := a.NewPolicy(context, original), a.NewBinding(context, original)
+	var policy, binding runtime.Object
+	if version == "v1" {
+		policy, err = a.applyV1(ctx, apply.MutatingAdmissionPolicyV1GVR, desiredPolicy)
+	} else {
+		policy, _, err = apply.MutatingAdmissionPolicy(ctx, a.client.AdmissionregistrationV1beta1(), a.recorder, desiredPolicy)
+	}
+	if err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	if version == "v1" {
+		binding, err = a.applyV1(ctx, apply.MutatingAdmissionPolicyBindingV1GVR, desiredBinding)
+	} else {
+		binding, _, err = apply.MutatingAdmissionPolicyBinding(ctx, a.client.AdmissionregistrationV1beta1(), a.recorder, desiredBinding)
+	}
+	if err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	// A MutatingAdmissionPolicy cannot return a warning, the Audit mode warns
+	// through a ValidatingAdmissionPolicy.
+	if original.Spec.RunOnceDurationOverrideConfig.Spec.GetMode() == appsv1.OverrideModeAudit {
+		err = a.applyAuditPolicy(ctx, context, original)
+	} else {
+		err = a.remover.RemoveAuditPolicy()
+	}
+	if err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	if current.Status.Resources.MutatingAdmissionPolicyRef, err = reference.GetReference(policy); err != nil {
+		handleErr = NewInstallReadinessError(appsv1.CannotSetReference, err)
+		return
+	}
+	if current.Status.Resources.MutatingAdmissionPolicyBindingRef, err = reference.GetReference(binding); err != nil {
+		handleErr = NewInstallReadinessError(appsv1.CannotSetReference, err)
+		return
+	}
+
+	klog.V(2).Infof("key=%s resource=MutatingAdmissionPolicy/%s version=%s is in sync", original.Name, desiredPolicy.Name, version)
+	return
+}
+
+// applyV1 applies the given v1beta1 object through the v1 API.
+func (a *admissionPolicyHandler) applyV1(ctx gocontext.Context, resource schema.GroupVersionResource, object runtime.Object) (runtime.Object, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+
+	required := &unstructured.Unstructured{Object: content}
+	required.SetAPIVersion(resource.GroupVersion().String())
+	actual, _, err := apply.Unstructured(ctx, a.dynamicClient, a.recorder, resource, required)
+	return actual, err
+}
+
+// applyAuditPolicy applies the ValidatingAdmissionPolicy and its binding that
+// warn about and audit the pods the override would change.
+func (a *admissionPolicyHandler) applyAuditPolicy(ctx gocontext.Context, context *ReconcileRequestContext, cro *appsv1.RunOnceDurationOverride) error {
+	policy := a.asset.AuditValidatingAdmissionPolicy().WithConfiguration(cro.Spec.RunOnceDurationOverrideConfig.Spec).New()
+	context.ControllerSetter().Set(policy, cro)
+	if _, _, err := apply.ValidatingAdmissionPolicy(ctx, a.client.AdmissionregistrationV1(), a.recorder, policy); err != nil {
+		return err
+	}
+
+	binding := a.asset.AuditValidatingAdmissionPolicyBinding().WithSelectors(cro.Spec.Webhook.NamespaceSelector, cro.Spec.Webhook.ObjectSelector).New()
+	context.ControllerSetter().Set(binding, cro)
+	_, _, err := apply.ValidatingAdmissionPolicyBinding(ctx, a.client.AdmissionregistrationV1(), a.recorder, binding)
+	return err
+}
+
+// NewPolicy returns the MutatingAdmissionPolicy generated from the configuration
+// and the webhook failure policy of the RunOnceDurationOverride spec.
+func (a *admissionPolicyHandler) NewPolicy(context *ReconcileRequestContext, cro *appsv1.RunOnceDurationOverride) *admissionregistrationv1beta1.MutatingAdmissionPolicy {
+	desired := a.asset.MutatingAdmissionPolicy().
+		WithConfiguration(cro.Spec.RunOnceDurationOverrideConfig.Spec).
+		WithFailurePolicy(cro.Spec.Webhook.FailurePolicy).
+		New()
+	context.ControllerSetter().Set(desired, cro)
+	return desired
+}
+
+// NewBinding returns the MutatingAdmissionPolicyBinding with the selectors of the
+// RunOnceDurationOverride spec.
+func (a *admissionPolicyHandler) NewBinding(context *ReconcileRequestContext, cro *appsv1.RunOnceDurationOverride) *admissionregistrationv1beta1.MutatingAdmissionPolicyBinding {
+	desired := a.asset.MutatingAdmissionPolicyBinding().WithSelectors(cro.Spec.Webhook.NamespaceSelector, cro.Spec.Webhook.ObjectSelector).New()
+	context.ControllerSetter().Set(desired, cro)
+	return desired
+}
+
+func NewWebhookModeHandler(remover *removalHandler) *webhookModeHandler {
+	return &webhookModeHandler{
+		remover: remover,
+	}
+}
+
+// webhookModeHandler runs first in the Webhook deployment mode. It removes the
+// resources left behind by the AdmissionPolicy mode, and reports when
+// AdmissionPolicy was requested but is not served by the cluster.
+type webhookModeHandler struct {
+	remover *removalHandler
+}
+
+func (w *webhookModeHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
+	current = original
+
+	if original.Status.DeploymentMode == appsv1.DeploymentModeAdmissionPolicy {
+		if err := w.remover.RemoveAdmissionPolicy(); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.CannotRemoveOperand, err)
+			return
+		}
+
+		current.Status.Resources.MutatingAdmissionPolicyRef = nil
+		current.Status.Resources.MutatingAdmissionPolicyBindingRef = nil
+		klog.V(2).Infof("key=%s switched to deployment mode %s", original.Name, appsv1.DeploymentModeWebhook)
+	}
+	current.Status.DeploymentMode = appsv1.DeploymentModeWebhook
+
+	if original.Spec.GetDeploymentMode() == appsv1.DeploymentModeAdmissionPolicy {
+		v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+			Type:    appsv1.DeploymentModeFallback,
+			Status:  operatorv1.ConditionTrue,
+			Reason:  appsv1.AdmissionPolicyNotServed,
+			Message: "the MutatingAdmissionPolicy API is not served, falling back to the Webhook deployment mode",
+		})
+		return
+	}
+
+	v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+		Type:   appsv1.DeploymentModeFallback,
+		Status: operatorv1.ConditionFalse,
+		Reason: appsv1.AsExpected,
+	})
+	return
+}
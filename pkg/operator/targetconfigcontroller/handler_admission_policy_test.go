@@ -0,0 +1,239 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
+	applyfake "github.com/openshift/run-once-duration-override-operator/pkg/apply/fake"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+func newTestAdmissionPolicyHandler(objects ...runtime.Object) (*admissionPolicyHandler, *webhookModeHandler, *kubefake.Clientset, *asset.Asset) {
+	operandAsset := asset.New(createTestOperandContext())
+	fakeKubeClient := kubefake.NewClientset(objects...)
+	fakeKubeClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: admissionregistrationv1beta1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{{Name: "mutatingadmissionpolicies"}, {Name: "mutatingadmissionpolicybindings"}},
+		},
+	}
+	recorder := events.NewLoggingEventRecorder("test", clock.RealClock{})
+	remover := NewRemovalHandler(fakeKubeClient, recorder, operandAsset)
+	policies := NewAdmissionPolicyDiscovery(fakeKubeClient.Discovery())
+
+	return NewAdmissionPolicyHandler(fakeKubeClient, applyfake.NewSimpleDynamicClient(runtime.NewScheme()), recorder, operandAsset, remover, policies), NewWebhookModeHandler(remover), fakeKubeClient, operandAsset
+}
+
+func TestAdmissionPolicyHandler(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	handler, _, fakeKubeClient, _ := newTestAdmissionPolicyHandler(
+		operandAsset.DaemonSet().New(),
+		operandAsset.NewMutatingWebhookConfiguration().New(),
+	)
+
+	rodoo := createTestRodoo(3600, withDeploymentMode(runoncedurationoverridev1.DeploymentModeAdmissionPolicy))
+	rodoo.Spec.RunOnceDurationOverrideConfig.Spec.NamespaceOverrides = []runoncedurationoverridev1.NamespaceActiveDeadlineOverride{
+		{
+			NamespaceSelector:     metav1.LabelSelector{MatchLabels: map[string]string{"tier": "batch"}},
+			ActiveDeadlineSeconds: 600,
+		},
+	}
+	rodoo.Spec.Webhook.FailurePolicy = admissionregistrationv1.Ignore
+	withWebhookHandlerStatus(rodoo)
+
+	current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.TODO()
+	if _, err := fakeKubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, operandAsset.NewMutatingWebhookConfiguration().Name(), metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected MutatingWebhookConfiguration to be removed on switch, got err=%v", err)
+	}
+	ds := operandAsset.DaemonSet().New()
+	if _, err := fakeKubeClient.AppsV1().DaemonSets(ds.Namespace).Get(ctx, ds.Name, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected DaemonSet to be removed on switch, got err=%v", err)
+	}
+
+	policy, err := fakeKubeClient.AdmissionregistrationV1beta1().MutatingAdmissionPolicies().Get(ctx, operandAsset.MutatingAdmissionPolicy().Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected MutatingAdmissionPolicy to be created: %v", err)
+	}
+	if policy.Spec.FailurePolicy == nil || *policy.Spec.FailurePolicy != admissionregistrationv1beta1.Ignore {
+		t.Errorf("expected failurePolicy Ignore, got %v", policy.Spec.FailurePolicy)
+	}
+	if expression := policy.Spec.Variables[0].Expression; !strings.Contains(expression, `"tier"`) || !strings.Contains(expression, "600") || !strings.HasSuffix(expression, "(3600)") {
+		t.Errorf("unexpected activeDeadlineSeconds expression %q", expression)
+	}
+
+	binding, err := fakeKubeClient.AdmissionregistrationV1beta1().MutatingAdmissionPolicyBindings().Get(ctx, operandAsset.MutatingAdmissionPolicyBinding().Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected MutatingAdmissionPolicyBinding to be created: %v", err)
+	}
+	if binding.Spec.PolicyName != policy.Name {
+		t.Errorf("expected binding to reference policy %q, got %q", policy.Name, binding.Spec.PolicyName)
+	}
+
+	if current.Status.DeploymentMode != runoncedurationoverridev1.DeploymentModeAdmissionPolicy {
+		t.Errorf("expected status deploymentMode AdmissionPolicy, got %q", current.Status.DeploymentMode)
+	}
+	if current.Status.Resources.DeploymentRef != nil || current.Status.Resources.MutatingAdmissionPolicyRef == nil || current.Status.Resources.MutatingAdmissionPolicyBindingRef == nil {
+		t.Errorf("unexpected status resources %+v", current.Status.Resources)
+	}
+	verifyCondition(t, current, runoncedurationoverridev1.DeploymentModeFallback, operatorv1.ConditionFalse, runoncedurationoverridev1.AsExpected)
+
+	// Switching the mode to Disabled removes the policy and its binding.
+	current.Spec.RunOnceDurationOverrideConfig.Spec.Mode = runoncedurationoverridev1.OverrideModeDisabled
+	current, _, err = handler.Handle(NewReconcileRequestContext(createTestOperandContext()), current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fakeKubeClient.AdmissionregistrationV1beta1().MutatingAdmissionPolicies().Get(ctx, policy.Name, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected MutatingAdmissionPolicy to be removed, got err=%v", err)
+	}
+	if current.Status.Resources.MutatingAdmissionPolicyRef != nil {
+		t.Errorf("expected MutatingAdmissionPolicyRef to be cleared")
+	}
+}
+
+func TestWebhookModeHandler(t *testing.T) {
+	handler, webhookMode, fakeKubeClient, operandAsset := newTestAdmissionPolicyHandler()
+
+	rodoo := createTestRodoo(3600, withDeploymentMode(runoncedurationoverridev1.DeploymentModeAdmissionPolicy))
+	current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Switching back to the Webhook mode removes the policy and its binding.
+	current.Spec.DeploymentMode = runoncedurationoverridev1.DeploymentModeWebhook
+	current, _, err = webhookMode.Handle(NewReconcileRequestContext(createTestOperandContext()), current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.TODO()
+	if _, err := fakeKubeClient.AdmissionregistrationV1beta1().MutatingAdmissionPolicies().Get(ctx, operandAsset.MutatingAdmissionPolicy().Name(), metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected MutatingAdmissionPolicy to be removed, got err=%v", err)
+	}
+	if _, err := fakeKubeClient.AdmissionregistrationV1beta1().MutatingAdmissionPolicyBindings().Get(ctx, operandAsset.MutatingAdmissionPolicyBinding().Name(), metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected MutatingAdmissionPolicyBinding to be removed, got err=%v", err)
+	}
+	if current.Status.DeploymentMode != runoncedurationoverridev1.DeploymentModeWebhook {
+		t.Errorf("expected status deploymentMode Webhook, got %q", current.Status.DeploymentMode)
+	}
+	verifyCondition(t, current, runoncedurationoverridev1.DeploymentModeFallback, operatorv1.ConditionFalse, runoncedurationoverridev1.AsExpected)
+
+	// Requesting AdmissionPolicy while running the webhook chain reports the fallback.
+	current.Spec.DeploymentMode = runoncedurationoverridev1.DeploymentModeAdmissionPolicy
+	current, _, err = webhookMode.Handle(NewReconcileRequestContext(createTestOperandContext()), current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyCondition(t, current, runoncedurationoverridev1.DeploymentModeFallback, operatorv1.ConditionTrue, runoncedurationoverridev1.AdmissionPolicyNotServed)
+}
+
+func TestAdmissionPolicyHandlerV1(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	fakeKubeClient := kubefake.NewClientset()
+	fakeKubeClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "admissionregistration.k8s.io/v1",
+			APIResources: []metav1.APIResource{{Name: "mutatingadmissionpolicies"}, {Name: "mutatingadmissionpolicybindings"}},
+		},
+	}
+	fakeDynamicClient := applyfake.NewSimpleDynamicClient(runtime.NewScheme())
+	recorder := events.NewLoggingEventRecorder("test", clock.RealClock{})
+	remover := NewRemovalHandler(fakeKubeClient, recorder, operandAsset).WithDynamicClient(fakeDynamicClient)
+	handler := NewAdmissionPolicyHandler(fakeKubeClient, fakeDynamicClient, recorder, operandAsset, remover, NewAdmissionPolicyDiscovery(fakeKubeClient.Discovery()))
+
+	rodoo := createTestRodoo(3600, withDeploymentMode(runoncedurationoverridev1.DeploymentModeAdmissionPolicy))
+	current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.TODO()
+	policy, err := fakeDynamicClient.Resource(apply.MutatingAdmissionPolicyV1GVR).Get(ctx, operandAsset.MutatingAdmissionPolicy().Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected MutatingAdmissionPolicy to be applied through v1: %v", err)
+	}
+	if policy.GetAPIVersion() != "admissionregistration.k8s.io/v1" {
+		t.Errorf("expected apiVersion admissionregistration.k8s.io/v1, got %q", policy.GetAPIVersion())
+	}
+	if _, err := fakeDynamicClient.Resource(apply.MutatingAdmissionPolicyBindingV1GVR).Get(ctx, operandAsset.MutatingAdmissionPolicyBinding().Name(), metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected MutatingAdmissionPolicyBinding to be applied through v1: %v", err)
+	}
+	if _, err := fakeKubeClient.AdmissionregistrationV1beta1().MutatingAdmissionPolicies().Get(ctx, operandAsset.MutatingAdmissionPolicy().Name(), metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected no v1beta1 MutatingAdmissionPolicy, got err=%v", err)
+	}
+	if ref := current.Status.Resources.MutatingAdmissionPolicyRef; ref == nil || ref.APIVersion != "admissionregistration.k8s.io/v1" {
+		t.Errorf("unexpected MutatingAdmissionPolicyRef %+v", ref)
+	}
+
+	if err := remover.RemoveAdmissionPolicy(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fakeDynamicClient.Resource(apply.MutatingAdmissionPolicyV1GVR).Get(ctx, operandAsset.MutatingAdmissionPolicy().Name(), metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected MutatingAdmissionPolicy to be removed, got err=%v", err)
+	}
+}
+
+func TestAdmissionPolicyHandlerAudit(t *testing.T) {
+	handler, _, fakeKubeClient, operandAsset := newTestAdmissionPolicyHandler()
+
+	rodoo := createTestRodoo(3600, withDeploymentMode(runoncedurationoverridev1.DeploymentModeAdmissionPolicy))
+	rodoo.Spec.RunOnceDurationOverrideConfig.Spec.Mode = runoncedurationoverridev1.OverrideModeAudit
+	current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.TODO()
+	policy, err := fakeKubeClient.AdmissionregistrationV1beta1().MutatingAdmissionPolicies().Get(ctx, operandAsset.MutatingAdmissionPolicy().Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected MutatingAdmissionPolicy to be created: %v", err)
+	}
+	if operations := policy.Spec.MatchConstraints.ResourceRules[0].Operations; len(operations) != 2 || operations[1] != admissionregistrationv1beta1.Update {
+		t.Errorf("expected operations [CREATE UPDATE], got %v", operations)
+	}
+	if policy.Spec.FailurePolicy == nil || *policy.Spec.FailurePolicy != admissionregistrationv1beta1.Fail {
+		t.Errorf("expected the default failurePolicy Fail, got %v", policy.Spec.FailurePolicy)
+	}
+
+	if _, err := fakeKubeClient.AdmissionregistrationV1().ValidatingAdmissionPolicies().Get(ctx, operandAsset.AuditValidatingAdmissionPolicy().Name(), metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected ValidatingAdmissionPolicy to be created in Audit mode: %v", err)
+	}
+	binding, err := fakeKubeClient.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Get(ctx, operandAsset.AuditValidatingAdmissionPolicyBinding().Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected ValidatingAdmissionPolicyBinding to be created in Audit mode: %v", err)
+	}
+	if actions := binding.Spec.ValidationActions; len(actions) != 2 || actions[0] != admissionregistrationv1.Warn || actions[1] != admissionregistrationv1.Audit {
+		t.Errorf("expected validationActions [Warn Audit], got %v", actions)
+	}
+
+	// Switching the mode to Enforce removes the audit policy.
+	current.Spec.RunOnceDurationOverrideConfig.Spec.Mode = runoncedurationoverridev1.OverrideModeEnforce
+	if _, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), current); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fakeKubeClient.AdmissionregistrationV1().ValidatingAdmissionPolicies().Get(ctx, operandAsset.AuditValidatingAdmissionPolicy().Name(), metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected ValidatingAdmissionPolicy to be removed, got err=%v", err)
+	}
+	if _, err := fakeKubeClient.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Get(ctx, operandAsset.AuditValidatingAdmissionPolicyBinding().Name(), metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected ValidatingAdmissionPolicyBinding to be removed, got err=%v", err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
 	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
 	"github.com/openshift/run-once-duration-override-operator/pkg/deploy"
+	"github.com/openshift/run-once-duration-override-operator/pkg/operator/metrics"
 )
 
 func NewAvailabilityHandler(asset *asset.Asset, deploy deploy.Interface) *availabilityHandler {
@@ -29,10 +30,19 @@ func (a *availabilityHandler) Handle(context *ReconcileRequestContext, original
 	current = original
 
 	available, err := a.deploy.IsAvailable()
+	metrics.SetWorkloadAvailable(string(original.Spec.GetWorkload()), available)
 
 	switch {
 	case available:
-		return
+		v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+			Type:   "Available",
+			Status: operatorv1.ConditionTrue,
+		})
+		v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+			Type:   operatorv1.OperatorStatusTypeProgressing,
+			Status: operatorv1.ConditionFalse,
+			Reason: appsv1.AsExpected,
+		})
 	case err == nil:
 		v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
 			Type:    "Available",
@@ -54,6 +64,14 @@ func (a *availabilityHandler) Handle(context *ReconcileRequestContext, original
 			Reason:  appsv1.InternalError,
 			Message: err.Error(),
 		})
+		// The workload exists, and is waiting for its pods to be updated,
+		// scheduled or available.
+		v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+			Type:    operatorv1.OperatorStatusTypeProgressing,
+			Status:  operatorv1.ConditionTrue,
+			Reason:  appsv1.RollingOut,
+			Message: err.Error(),
+		})
 	}
 
 	return
@@ -2,8 +2,11 @@ package targetconfigcontroller
 
 import (
 	gocontext "context"
+	"fmt"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -12,21 +15,14 @@ import (
 	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openshift/library-go/pkg/operator/events"
-	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
 	"github.com/openshift/run-once-duration-override-operator/pkg/apis/reference"
 	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
 	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
 	"github.com/openshift/run-once-duration-override-operator/pkg/cert"
 )
 
 var (
-	// DefaultCertValidFor is the default duration a cert will be valid for.
-	DefaultCertValidFor = time.Hour * 24 * 365
-
-	// DefaultCertRotateThreshold is the default threshold preceding the expiration date
-	// the operator will make attempt(s) to rotate the certs.
-	DefaultCertRotateThreshold = time.Hour * 48
-
 	Organization = "Red Hat, Inc."
 )
 
@@ -66,19 +62,45 @@ func (c *certGenerationHandler) Handle(context *ReconcileRequestContext, origina
 		return
 	}
 
+	hosts := ServingHosts(c.asset, original)
+	stage := false
+
 	switch {
 	case k8serrors.IsNotFound(secretGetErr) || k8serrors.IsNotFound(configMapGetErr):
 		ensure = true
-	case original.IsTimeToRotateCert() || !cert.IsPopulated(currentSecret):
+	case !cert.IsPopulated(currentSecret):
 		ensure = true
 	case original.Status.CertsRotateAt.IsZero():
 		ensure = true
+	case !cert.CoversHosts(currentSecret, hosts):
+		klog.V(2).Infof("key=%s resource=%T/%s serving cert does not cover hosts %v", original.Name, currentSecret, currentSecret.Name, hosts)
+		ensure = true
+	case original.Status.CertRotation.Phase != "":
+		// A staged rotation is in progress, certRotationHandler drives it to completion.
+		klog.V(2).Infof("key=%s cert rotation in phase %s", original.Name, original.Status.CertRotation.Phase)
+	case original.IsTimeToRotateCert() || c.IsOutdated(original, currentSecret):
+		// The pods are serving a cert the API server trusts, rotate without
+		// ever leaving them with a cert signed by an untrusted CA.
+		stage = true
+	}
+
+	if stage {
+		configmap, err := c.StageRotation(context, original, currentSecret, currentConfigMap, hosts)
+		if err != nil {
+			handleErr = NewInstallReadinessError(appsv1.CannotGenerateCert, err)
+			return
+		}
+
+		currentConfigMap = configmap
+		klog.V(2).Infof("key=%s resource=%T/%s new CA added to the CA bundle", original.Name, currentConfigMap, currentConfigMap.Name)
+		context.Recorder().Event(original, corev1.EventTypeNormal, "CertRotationStarted", "Staged a new CA and serving cert, waiting for the API server to trust the new CA")
 	}
 
 	if ensure {
 		// generate cert.
-		expiresAt := time.Now().Add(DefaultCertValidFor)
-		bundle, err := cert.GenerateWithLocalhostServing(expiresAt, Organization)
+		config := &original.Spec.Certificate
+		expiresAt := time.Now().Add(config.GetValidity())
+		bundle, err := cert.GenerateWithServing(expiresAt, Organization, hosts, config.GetKeyAlgorithm())
 		if err != nil {
 			handleErr = NewInstallReadinessError(appsv1.CannotGenerateCert, err)
 			return
@@ -94,7 +116,7 @@ func (c *certGenerationHandler) Handle(context *ReconcileRequestContext, origina
 		desiredSecret.Data["tls.key"] = bundle.Serving.ServiceKey
 		desiredSecret.Data["tls.crt"] = bundle.Serving.ServiceCert
 
-		secret, _, err := resourceapply.ApplySecret(gocontext.TODO(), c.client.CoreV1(), c.recorder, desiredSecret)
+		secret, _, err := apply.Secret(gocontext.TODO(), c.client.CoreV1(), c.recorder, desiredSecret)
 		if err != nil {
 			handleErr = NewInstallReadinessError(appsv1.CannotGenerateCert, err)
 			return
@@ -109,17 +131,19 @@ func (c *certGenerationHandler) Handle(context *ReconcileRequestContext, origina
 		}
 		desiredConfigMap.Data["service-ca.crt"] = string(bundle.ServingCertCA)
 
-		configmap, _, err := resourceapply.ApplyConfigMap(gocontext.TODO(), c.client.CoreV1(), c.recorder, desiredConfigMap)
+		configmap, _, err := apply.ConfigMap(gocontext.TODO(), c.client.CoreV1(), c.recorder, desiredConfigMap)
 		if err != nil {
 			handleErr = NewInstallReadinessError(appsv1.CannotGenerateCert, err)
 			return
 		}
 
 		context.SetBundle(bundle)
-		current.Status.CertsRotateAt = metav1.NewTime(expiresAt.Add(-1 * DefaultCertRotateThreshold))
+		current.Status.CertsRotateAt = metav1.NewTime(expiresAt.Add(-1 * config.GetRotationThreshold()))
+		current.Status.CertRotation.Phase = ""
 
 		currentSecret = secret
 		klog.V(2).Infof("key=%s resource=%T/%s successfully ensured", original.Name, currentSecret, currentSecret.Name)
+		context.Recorder().Eventf(original, corev1.EventTypeNormal, "ServingCertGenerated", "Generated a new CA and serving cert, valid until %s", expiresAt.UTC().Format(time.RFC3339))
 
 		currentConfigMap = configmap
 		klog.V(2).Infof("key=%s resource=%T/%s successfully ensured", original.Name, currentConfigMap, currentConfigMap.Name)
@@ -151,3 +175,88 @@ func (c *certGenerationHandler) Handle(context *ReconcileRequestContext, origina
 
 	return
 }
+
+// IsOutdated returns true if the serving cert in the given Secret object does
+// not match spec.certificate: its key algorithm differs, it outlives the
+// configured validity, or it is within the configured rotation threshold.
+func (c *certGenerationHandler) IsOutdated(cro *appsv1.RunOnceDurationOverride, secret *corev1.Secret) bool {
+	config := &cro.Spec.Certificate
+
+	current, err := cert.PEMToCert(secret.Data["tls.crt"])
+	if err != nil {
+		return true
+	}
+
+	now := time.Now()
+	switch {
+	case cert.KeyAlgorithmOf(current) != config.GetKeyAlgorithm():
+	case current.NotAfter.After(now.Add(config.GetValidity())):
+	case current.NotAfter.Add(-1 * config.GetRotationThreshold()).Before(now):
+	default:
+		return false
+	}
+
+	klog.V(2).Infof("key=%s serving cert does not match the certificate settings, rotating", cro.Name)
+	return true
+}
+
+// StageRotation starts a staged rotation: it generates a new CA and serving
+// cert into the staged Secret, and adds the new CA to the CA bundle next to the
+// old one. The pods keep serving the current cert until the API server trusts
+// the new CA, see certRotationHandler.
+func (c *certGenerationHandler) StageRotation(context *ReconcileRequestContext, cro *appsv1.RunOnceDurationOverride, currentSecret *corev1.Secret, currentConfigMap *corev1.ConfigMap, hosts []string) (*corev1.ConfigMap, error) {
+	config := &cro.Spec.Certificate
+	expiresAt := time.Now().Add(config.GetValidity())
+	bundle, err := cert.GenerateWithServing(expiresAt, Organization, hosts, config.GetKeyAlgorithm())
+	if err != nil {
+		return nil, err
+	}
+
+	desiredSecret := c.asset.ServiceServingSecret().NewStaged()
+	context.ControllerSetter().Set(desiredSecret, cro)
+	desiredSecret.Data["tls.key"] = bundle.Serving.ServiceKey
+	desiredSecret.Data["tls.crt"] = bundle.Serving.ServiceCert
+	desiredSecret.Data["ca.crt"] = bundle.ServingCertCA
+
+	if _, _, err := apply.Secret(gocontext.TODO(), c.client.CoreV1(), c.recorder, desiredSecret); err != nil {
+		return nil, err
+	}
+
+	trusted := fmt.Sprintf("%s\n%s", strings.TrimSpace(currentConfigMap.Data["service-ca.crt"]), bundle.ServingCertCA)
+
+	desiredConfigMap := c.asset.CABundleConfigMap().New()
+	context.ControllerSetter().Set(desiredConfigMap, cro)
+	desiredConfigMap.Data = map[string]string{
+		"service-ca.crt": trusted,
+	}
+
+	configmap, _, err := apply.ConfigMap(gocontext.TODO(), c.client.CoreV1(), c.recorder, desiredConfigMap)
+	if err != nil {
+		return nil, err
+	}
+
+	context.SetBundle(&cert.Bundle{
+		Serving: cert.Serving{
+			ServiceKey:  currentSecret.Data["tls.key"],
+			ServiceCert: currentSecret.Data["tls.crt"],
+		},
+		ServingCertCA: []byte(trusted),
+	})
+
+	cro.Status.CertsRotateAt = metav1.NewTime(expiresAt.Add(-1 * config.GetRotationThreshold()))
+	cro.Status.CertRotation.Phase = appsv1.CertRotationPhaseTrustingNewCA
+	cro.Status.CertRotation.LastTransitionTime = metav1.Now()
+
+	return configmap, nil
+}
+
+// ServingHosts returns the hosts the serving cert must be valid for. The
+// Deployment workload is reached through its Service rather than localhost.
+func ServingHosts(asset *asset.Asset, cro *appsv1.RunOnceDurationOverride) []string {
+	hosts := []string{"localhost"}
+	if cro.Spec.GetWorkload() == appsv1.WorkloadDeployment {
+		hosts = append(hosts, asset.Service().Hosts()...)
+	}
+
+	return hosts
+}
@@ -1,22 +1,31 @@
 package targetconfigcontroller
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 
+	operatorv1 "github.com/openshift/api/operator/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"k8s.io/client-go/kubernetes"
 	listerscorev1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
 	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
 	"github.com/openshift/run-once-duration-override-operator/pkg/cert"
 )
 
-func NewCertReadyHandler(client kubernetes.Interface, secretLister listerscorev1.SecretLister, configMapLister listerscorev1.ConfigMapLister) *certReadyHandler {
+func NewCertReadyHandler(client kubernetes.Interface, secretLister listerscorev1.SecretLister, configMapLister listerscorev1.ConfigMapLister, asset *asset.Asset) *certReadyHandler {
 	return &certReadyHandler{
 		client:          client,
 		secretLister:    secretLister,
 		configMapLister: configMapLister,
+		asset:           asset,
 	}
 }
 
@@ -24,45 +33,189 @@ type certReadyHandler struct {
 	client          kubernetes.Interface
 	secretLister    listerscorev1.SecretLister
 	configMapLister listerscorev1.ConfigMapLister
+	asset           *asset.Asset
+	optional        bool
+}
+
+// WithOptionalBundle makes the handler only load the serving cert bundle into
+// the context when it is usable. A bundle that is missing or invalid leaves the
+// context without one and is not reported, the cert management controller
+// reports it, so that it does not hold up the other areas of the operand.
+func (c *certReadyHandler) WithOptionalBundle() *certReadyHandler {
+	c.optional = true
+	return c
 }
 
 func (c *certReadyHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
 	current = original
+
+	bundle, userProvidedErr, err := c.load(context, original)
+	if c.optional {
+		if err == nil && userProvidedErr == nil {
+			context.SetBundle(bundle)
+		}
+		return
+	}
+
+	switch {
+	case userProvidedErr != nil:
+		handleErr = userProvidedCertUnusable(current, userProvidedErr)
+		return
+	case err != nil:
+		handleErr = err
+		return
+	}
+
+	context.SetBundle(bundle)
+	if original.Spec.GetCertSource() == appsv1.CertSourceUserProvided {
+		v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+			Type:   operatorv1.OperatorStatusTypeDegraded,
+			Status: operatorv1.ConditionFalse,
+			Reason: appsv1.AsExpected,
+		})
+	}
+
+	// The CA bundle is left out so that adding or dropping a CA during a staged
+	// rotation does not roll the pods.
+	current.Status.Hash.ServingCert = bundle.ServingHash()
+
+	if err := c.SetCertificatesStatus(current, bundle); err != nil {
+		klog.Warningf("key=%s failed to parse the serving certs - %s", original.Name, err.Error())
+	}
+
+	klog.V(2).Infof("key=%s cert check passed", original.Name)
+	return
+}
+
+// load returns the serving cert bundle of the context, or the one read from the
+// Secret and ConfigMap the cert management controller recorded in status.
+// userProvidedErr is set when the cert provided by the user is not usable.
+func (c *certReadyHandler) load(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (bundle *cert.Bundle, userProvidedErr, err error) {
+	userProvided := original.Spec.GetCertSource() == appsv1.CertSourceUserProvided
 	resources := original.Status.Resources
 
-	if context.GetBundle() == nil {
-		secret, err := c.secretLister.Secrets(context.WebhookNamespace()).Get(resources.ServiceCertSecretRef.Name)
-		if err != nil {
-			handleErr = NewInstallReadinessError(appsv1.CertNotAvailable, err)
+	bundle = context.GetBundle()
+	if bundle == nil {
+		// The cert management controller sets the references once the serving
+		// cert has been issued.
+		if resources.ServiceCertSecretRef == nil || resources.ServiceCAConfigMapRef == nil {
+			err = NewInstallReadinessError(appsv1.CertNotAvailable, errors.New("waiting for the serving cert to be issued"))
+			return
+		}
+
+		secret, getErr := c.secretLister.Secrets(context.WebhookNamespace()).Get(resources.ServiceCertSecretRef.Name)
+		if getErr != nil {
+			err = NewInstallReadinessError(appsv1.CertNotAvailable, getErr)
 			return
 		}
 
-		configmap, err := c.configMapLister.ConfigMaps(context.WebhookNamespace()).Get(resources.ServiceCAConfigMapRef.Name)
-		if err != nil {
-			handleErr = NewInstallReadinessError(appsv1.CertNotAvailable, err)
+		configmap, getErr := c.configMapLister.ConfigMaps(context.WebhookNamespace()).Get(resources.ServiceCAConfigMapRef.Name)
+		if getErr != nil {
+			err = NewInstallReadinessError(appsv1.CertNotAvailable, getErr)
 			return
 		}
 
-		servingCertCA := []byte(configmap.Data["service-ca.crt"])
-		bundle := &cert.Bundle{
+		bundle = &cert.Bundle{
 			Serving: cert.Serving{
 				ServiceKey:  secret.Data["tls.key"],
 				ServiceCert: secret.Data["tls.crt"],
 			},
-			ServingCertCA: servingCertCA,
+			ServingCertCA: []byte(configmap.Data[caBundleKey(original)]),
 		}
 
-		if err := bundle.Validate(); err != nil {
-			handleErr = NewInstallReadinessError(appsv1.CertNotAvailable, fmt.Errorf("certs not populated - %s", err.Error()))
+		if validateErr := bundle.Validate(); validateErr != nil {
+			validateErr = fmt.Errorf("certs not populated - %s", validateErr.Error())
+			if userProvided {
+				userProvidedErr = validateErr
+				return
+			}
+
+			err = NewInstallReadinessError(appsv1.CertNotAvailable, validateErr)
 			return
 		}
+	}
 
-		context.SetBundle(bundle)
+	if userProvided {
+		userProvidedErr = c.VerifyUserProvided(bundle, ServingHosts(c.asset, original))
 	}
 
-	bundle := context.GetBundle()
-	current.Status.Hash.ServingCert = bundle.Hash()
+	return
+}
+
+// VerifyUserProvided checks that the serving cert of the given bundle is signed
+// by its CA, valid for all of the given hosts and within its validity window.
+func (c *certReadyHandler) VerifyUserProvided(bundle *cert.Bundle, hosts []string) (err error) {
+	ca, err := cert.PEMToCert(bundle.ServingCertCA)
+	if err != nil {
+		err = fmt.Errorf("invalid CA bundle - %s", err.Error())
+		return
+	}
+
+	serving, err := cert.PEMToCert(bundle.ServiceCert)
+	if err != nil {
+		err = fmt.Errorf("invalid serving cert - %s", err.Error())
+		return
+	}
+
+	if _, err = tls.X509KeyPair(bundle.ServiceCert, bundle.ServiceKey); err != nil {
+		err = fmt.Errorf("serving cert does not match its private key - %s", err.Error())
+		return
+	}
+
+	for _, host := range hosts {
+		if err = cert.VerifyCert(ca, serving, host); err != nil {
+			err = fmt.Errorf("serving cert not valid for %s - %s", host, err.Error())
+			return
+		}
+	}
+
+	if !cert.Active(serving) {
+		err = fmt.Errorf("serving cert not active, valid from %s until %s", serving.NotBefore, serving.NotAfter)
+		return
+	}
 
-	klog.V(2).Infof("key=%s cert check passed", original.Name)
 	return
 }
+
+// SetCertificatesStatus describes the serving cert of the given bundle and the
+// CA that signed it in the status of the given object.
+func (c *certReadyHandler) SetCertificatesStatus(cro *appsv1.RunOnceDurationOverride, bundle *cert.Bundle) error {
+	serving, err := cert.PEMToCert(bundle.ServiceCert)
+	if err != nil {
+		return err
+	}
+
+	cas, err := cert.PEMToCerts(bundle.ServingCertCA)
+	if err != nil {
+		return err
+	}
+
+	status := &cro.Status.Certificates
+	if serial := serving.SerialNumber.Text(16); status.Serving.SerialNumber != serial {
+		status.LastRotationTime = metav1.Now()
+	}
+
+	status.Serving = certificateStatus(serving)
+	status.CA = appsv1.CertificateStatus{}
+	if ca := cert.IssuerOf(serving, cas); ca != nil {
+		status.CA = certificateStatus(ca)
+	}
+
+	return nil
+}
+
+func certificateStatus(certificate *x509.Certificate) appsv1.CertificateStatus {
+	var ipAddresses []string
+	for _, ip := range certificate.IPAddresses {
+		ipAddresses = append(ipAddresses, ip.String())
+	}
+
+	return appsv1.CertificateStatus{
+		SerialNumber: certificate.SerialNumber.Text(16),
+		NotBefore:    metav1.NewTime(certificate.NotBefore),
+		NotAfter:     metav1.NewTime(certificate.NotAfter),
+		DNSNames:     certificate.DNSNames,
+		IPAddresses:  ipAddresses,
+		KeyAlgorithm: string(cert.KeyAlgorithmOf(certificate)),
+	}
+}
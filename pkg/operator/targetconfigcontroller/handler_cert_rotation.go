@@ -0,0 +1,144 @@
+package targetconfigcontroller
+
+import (
+	"bytes"
+	gocontext "context"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+	"github.com/openshift/run-once-duration-override-operator/pkg/cert"
+	"github.com/openshift/run-once-duration-override-operator/pkg/deploy"
+)
+
+func NewCertRotationHandler(client kubernetes.Interface, recorder events.Recorder, asset *asset.Asset, deploy deploy.Interface) *certRotationHandler {
+	return &certRotationHandler{
+		client:   client,
+		recorder: recorder,
+		asset:    asset,
+		deploy:   deploy,
+	}
+}
+
+// certRotationHandler drives a staged rotation started by certGenerationHandler.
+// It runs after the webhook configuration handler, so the CA bundle of the
+// request has been handed to the API server by the time it looks at it.
+//
+//   - TrustingNewCA: once the API server trusts both the old and the new CA, the
+//     staged serving cert is copied into the serving Secret.
+//   - RollingOutServingCert: once every pod serves the new cert, the old CA is
+//     dropped from the CA bundle and the staged Secret is removed.
+type certRotationHandler struct {
+	client   kubernetes.Interface
+	recorder events.Recorder
+	asset    *asset.Asset
+	deploy   deploy.Interface
+}
+
+func (c *certRotationHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
+	current = original
+
+	phase := original.Status.CertRotation.Phase
+	if phase == "" {
+		return
+	}
+
+	ctx := gocontext.TODO()
+	staged, err := c.client.CoreV1().Secrets(context.WebhookNamespace()).Get(ctx, c.asset.ServiceServingSecret().StagedName(), metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		// Start over, certGenerationHandler stages a new rotation on the next sync.
+		klog.Warningf("key=%s staged serving cert is gone, restarting cert rotation", original.Name)
+		context.Recorder().Eventf(original, corev1.EventTypeWarning, "CertRotationRestarted", "Secret %s with the staged serving cert is gone, restarting the cert rotation", c.asset.ServiceServingSecret().StagedName())
+		current.Status.CertRotation.Phase = ""
+		current.Status.CertRotation.LastTransitionTime = metav1.Now()
+		current.Status.CertsRotateAt = metav1.Now()
+		return
+	}
+	if err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	bundle := context.GetBundle()
+	newCA := staged.Data["ca.crt"]
+
+	switch phase {
+	case appsv1.CertRotationPhaseTrustingNewCA:
+		if bundle == nil || !bytes.Contains(bundle.ServingCertCA, bytes.TrimSpace(newCA)) {
+			klog.V(2).Infof("key=%s waiting for the new CA to be added to the CA bundle", original.Name)
+			return
+		}
+
+		desired := c.asset.ServiceServingSecret().New()
+		context.ControllerSetter().Set(desired, original)
+		desired.Data["tls.key"] = staged.Data["tls.key"]
+		desired.Data["tls.crt"] = staged.Data["tls.crt"]
+
+		if _, _, err := apply.Secret(ctx, c.client.CoreV1(), c.recorder, desired); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.CannotGenerateCert, err)
+			return
+		}
+
+		current.Status.CertRotation.Phase = appsv1.CertRotationPhaseRollingOutServingCert
+		current.Status.CertRotation.LastTransitionTime = metav1.Now()
+		klog.V(2).Infof("key=%s new CA is trusted, rolling out the new serving cert", original.Name)
+		context.Recorder().Event(original, corev1.EventTypeNormal, "CertRotationProgressing", "The API server trusts the new CA, rolling out the new serving cert")
+
+	case appsv1.CertRotationPhaseRollingOutServingCert:
+		if bundle == nil || !bytes.Equal(bundle.ServiceCert, staged.Data["tls.crt"]) {
+			klog.V(2).Infof("key=%s waiting for the new serving cert to be picked up", original.Name)
+			return
+		}
+
+		// The workload is ready at this point of the chain, make sure it is
+		// ready with the new serving cert and not with a stale one.
+		_, accessor, err := c.deploy.Get()
+		if err != nil {
+			handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+			return
+		}
+		if accessor.GetAnnotations()[c.asset.Values().ServingCertHashAnnotationKey] != current.Status.Hash.ServingCert {
+			klog.V(2).Infof("key=%s waiting for the new serving cert to roll out", original.Name)
+			return
+		}
+
+		desired := c.asset.CABundleConfigMap().New()
+		context.ControllerSetter().Set(desired, original)
+		desired.Data = map[string]string{
+			"service-ca.crt": string(newCA),
+		}
+
+		if _, _, err := apply.ConfigMap(ctx, c.client.CoreV1(), c.recorder, desired); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.CannotGenerateCert, err)
+			return
+		}
+
+		if _, _, err := resourceapply.DeleteSecret(ctx, c.client.CoreV1(), c.recorder, staged); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+			return
+		}
+
+		context.SetBundle(&cert.Bundle{
+			Serving:       bundle.Serving,
+			ServingCertCA: newCA,
+		})
+
+		now := metav1.Now()
+		current.Status.CertRotation.Phase = ""
+		current.Status.CertRotation.LastTransitionTime = now
+		current.Status.CertRotation.LastCompletionTime = now
+		klog.V(2).Infof("key=%s cert rotation complete, old CA dropped from the CA bundle", original.Name)
+		context.Recorder().Event(original, corev1.EventTypeNormal, "CertRotationCompleted", "The new serving cert is rolled out, the old CA is dropped from the CA bundle")
+	}
+
+	return
+}
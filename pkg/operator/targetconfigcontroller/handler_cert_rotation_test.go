@@ -0,0 +1,322 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+	"github.com/openshift/run-once-duration-override-operator/pkg/cert"
+	"github.com/openshift/run-once-duration-override-operator/pkg/deploy"
+)
+
+func TestCertRotationStaged(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	recorder := events.NewLoggingEventRecorder("test", clock.RealClock{})
+
+	old, err := cert.GenerateWithLocalhostServing(time.Now().Add(time.Hour), Organization)
+	if err != nil {
+		t.Fatalf("failed to generate cert: %v", err)
+	}
+
+	secret := operandAsset.ServiceServingSecret().New()
+	secret.Data["tls.key"] = old.ServiceKey
+	secret.Data["tls.crt"] = old.ServiceCert
+	configmap := operandAsset.CABundleConfigMap().New()
+	configmap.Data = map[string]string{"service-ca.crt": string(old.ServingCertCA)}
+
+	fakeKubeClient := kubefake.NewClientset(secret, configmap)
+	kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+	secretLister := kubeInformerFactory.Core().V1().Secrets().Lister()
+	configMapLister := kubeInformerFactory.Core().V1().ConfigMaps().Lister()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeInformerFactory.Start(ctx.Done())
+	kubeInformerFactory.WaitForCacheSync(ctx.Done())
+
+	rodoo := createTestRodoo(3600, nil)
+	withCertReadyStatus(rodoo)
+	rodoo.Status.CertsRotateAt = metav1.NewTime(time.Now().Add(-time.Minute))
+
+	// The rotation is staged: the pods keep the old serving cert, and the new
+	// CA is trusted next to the old one.
+	objectRecorder := record.NewFakeRecorder(10)
+	reconcileContext := NewReconcileRequestContext(createTestOperandContext()).WithRecorder(objectRecorder)
+	current, _, err := NewCertGenerationHandler(fakeKubeClient, recorder, secretLister, configMapLister, operandAsset).Handle(reconcileContext, rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current.Status.CertRotation.Phase != runoncedurationoverridev1.CertRotationPhaseTrustingNewCA {
+		t.Fatalf("expected phase %s, got %q", runoncedurationoverridev1.CertRotationPhaseTrustingNewCA, current.Status.CertRotation.Phase)
+	}
+	if !current.Status.CertsRotateAt.After(time.Now()) {
+		t.Errorf("expected CertsRotateAt to move to the new cert, got %s", current.Status.CertsRotateAt)
+	}
+
+	staged, err := fakeKubeClient.CoreV1().Secrets("test-namespace").Get(ctx, operandAsset.ServiceServingSecret().StagedName(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected staged Secret to be created: %v", err)
+	}
+	trusted, err := fakeKubeClient.CoreV1().ConfigMaps("test-namespace").Get(ctx, configmap.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if caBundle := trusted.Data["service-ca.crt"]; !strings.Contains(caBundle, strings.TrimSpace(string(old.ServingCertCA))) || !strings.Contains(caBundle, string(staged.Data["ca.crt"])) {
+		t.Errorf("expected the CA bundle to hold both CAs, got %q", caBundle)
+	}
+	if string(reconcileContext.GetBundle().ServiceCert) != string(old.ServiceCert) {
+		t.Errorf("expected the old serving cert to be kept while the new CA is trusted")
+	}
+
+	// The new CA is trusted, the serving Secret gets the new cert.
+	current, _, err = NewCertRotationHandler(fakeKubeClient, recorder, operandAsset, nil).Handle(reconcileContext, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current.Status.CertRotation.Phase != runoncedurationoverridev1.CertRotationPhaseRollingOutServingCert {
+		t.Fatalf("expected phase %s, got %q", runoncedurationoverridev1.CertRotationPhaseRollingOutServingCert, current.Status.CertRotation.Phase)
+	}
+	serving, err := fakeKubeClient.CoreV1().Secrets("test-namespace").Get(ctx, secret.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(serving.Data["tls.crt"]) != string(staged.Data["tls.crt"]) {
+		t.Errorf("expected the serving Secret to hold the staged cert")
+	}
+
+	// The pods serve the new cert, the old CA is dropped.
+	bundle := &cert.Bundle{
+		Serving:       cert.Serving{ServiceKey: serving.Data["tls.key"], ServiceCert: serving.Data["tls.crt"]},
+		ServingCertCA: []byte(trusted.Data["service-ca.crt"]),
+	}
+	current.Status.Hash.ServingCert = bundle.ServingHash()
+
+	ds := operandAsset.DaemonSet().New()
+	ds.Annotations = map[string]string{operandAsset.Values().ServingCertHashAnnotationKey: bundle.ServingHash()}
+	if _, err := fakeKubeClient.AppsV1().DaemonSets(ds.Namespace).Create(ctx, ds, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dsInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+	deployInterface := deploy.NewDaemonSetInstall(dsInformerFactory.Apps().V1().DaemonSets().Lister(), createTestOperandContext(), operandAsset, fakeKubeClient, recorder)
+	dsInformerFactory.Start(ctx.Done())
+	dsInformerFactory.WaitForCacheSync(ctx.Done())
+
+	reconcileContext = NewReconcileRequestContext(createTestOperandContext()).WithRecorder(objectRecorder)
+	reconcileContext.SetBundle(bundle)
+	current, _, err = NewCertRotationHandler(fakeKubeClient, recorder, operandAsset, deployInterface).Handle(reconcileContext, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current.Status.CertRotation.Phase != "" || current.Status.CertRotation.LastCompletionTime.IsZero() {
+		t.Errorf("expected the rotation to complete, got %+v", current.Status.CertRotation)
+	}
+	trusted, err = fakeKubeClient.CoreV1().ConfigMaps("test-namespace").Get(ctx, configmap.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trusted.Data["service-ca.crt"] != string(staged.Data["ca.crt"]) {
+		t.Errorf("expected the CA bundle to hold the new CA only")
+	}
+	if _, err := fakeKubeClient.CoreV1().Secrets("test-namespace").Get(ctx, staged.Name, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected staged Secret to be removed, got err=%v", err)
+	}
+
+	verifyEvents(t, objectRecorder, "CertRotationStarted", "CertRotationProgressing", "CertRotationCompleted")
+}
+
+// verifyEvents checks that the recorder got Events with the given reasons, in order.
+func verifyEvents(t *testing.T, recorder *record.FakeRecorder, reasons ...string) {
+	t.Helper()
+
+	for _, reason := range reasons {
+		select {
+		case event := <-recorder.Events:
+			if fields := strings.Fields(event); len(fields) < 2 || fields[1] != reason {
+				t.Errorf("expected an Event with reason %s, got %q", reason, event)
+			}
+		default:
+			t.Errorf("expected an Event with reason %s, got none", reason)
+		}
+	}
+
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("unexpected Event %q", event)
+	default:
+	}
+}
+
+func TestCertRotationHandlerStagedSecretMissing(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	fakeKubeClient := kubefake.NewClientset()
+	handler := NewCertRotationHandler(fakeKubeClient, events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset, nil)
+
+	rodoo := createTestRodoo(3600, nil)
+	withCertReadyStatus(rodoo)
+	rodoo.Status.CertRotation.Phase = runoncedurationoverridev1.CertRotationPhaseRollingOutServingCert
+
+	current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current.Status.CertRotation.Phase != "" {
+		t.Errorf("expected the rotation to be reset, got phase %q", current.Status.CertRotation.Phase)
+	}
+	if current.Status.CertsRotateAt.After(time.Now()) {
+		t.Errorf("expected a new rotation to be due, got CertsRotateAt=%s", current.Status.CertsRotateAt)
+	}
+}
+
+func TestCertGenerationHandlerCertificateConfig(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	recorder := events.NewLoggingEventRecorder("test", clock.RealClock{})
+
+	old, err := cert.GenerateWithLocalhostServing(time.Now().Add(365*24*time.Hour), Organization)
+	if err != nil {
+		t.Fatalf("failed to generate cert: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		certificate runoncedurationoverridev1.CertificateConfig
+		expectStage bool
+	}{
+		{name: "Defaults", expectStage: false},
+		{name: "KeyAlgorithmChanged", certificate: runoncedurationoverridev1.CertificateConfig{KeyAlgorithm: runoncedurationoverridev1.KeyAlgorithmECDSAP384}, expectStage: true},
+		{name: "ValidityShortened", certificate: runoncedurationoverridev1.CertificateConfig{Validity: &metav1.Duration{Duration: 90 * 24 * time.Hour}}, expectStage: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := operandAsset.ServiceServingSecret().New()
+			secret.Data["tls.key"] = old.ServiceKey
+			secret.Data["tls.crt"] = old.ServiceCert
+			configmap := operandAsset.CABundleConfigMap().New()
+			configmap.Data = map[string]string{"service-ca.crt": string(old.ServingCertCA)}
+
+			fakeKubeClient := kubefake.NewClientset(secret, configmap)
+			kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+			handler := NewCertGenerationHandler(fakeKubeClient, recorder, kubeInformerFactory.Core().V1().Secrets().Lister(), kubeInformerFactory.Core().V1().ConfigMaps().Lister(), operandAsset)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			kubeInformerFactory.Start(ctx.Done())
+			kubeInformerFactory.WaitForCacheSync(ctx.Done())
+
+			rodoo := createTestRodoo(3600, nil)
+			withCertReadyStatus(rodoo)
+			rodoo.Spec.Certificate = tt.certificate
+
+			current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			staged, err := fakeKubeClient.CoreV1().Secrets("test-namespace").Get(ctx, operandAsset.ServiceServingSecret().StagedName(), metav1.GetOptions{})
+			if !tt.expectStage {
+				if !k8serrors.IsNotFound(err) || current.Status.CertRotation.Phase != "" {
+					t.Errorf("expected no rotation, got phase %q err=%v", current.Status.CertRotation.Phase, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected a staged rotation: %v", err)
+			}
+
+			stagedCert, err := cert.PEMToCert(staged.Data["tls.crt"])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if algorithm := cert.KeyAlgorithmOf(stagedCert); algorithm != tt.certificate.GetKeyAlgorithm() {
+				t.Errorf("expected key algorithm %s, got %s", tt.certificate.GetKeyAlgorithm(), algorithm)
+			}
+			if stagedCert.NotAfter.After(time.Now().Add(tt.certificate.GetValidity())) {
+				t.Errorf("expected the staged cert to be valid for %s, expires at %s", tt.certificate.GetValidity(), stagedCert.NotAfter)
+			}
+			if expected := stagedCert.NotAfter.Add(-1 * tt.certificate.GetRotationThreshold()); current.Status.CertsRotateAt.Time.Sub(expected).Abs() > time.Second {
+				t.Errorf("expected CertsRotateAt %s, got %s", expected, current.Status.CertsRotateAt)
+			}
+		})
+	}
+}
+
+func TestCertReadyHandlerCertificatesStatus(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	fakeKubeClient := kubefake.NewClientset()
+	kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+	handler := NewCertReadyHandler(fakeKubeClient, kubeInformerFactory.Core().V1().Secrets().Lister(), kubeInformerFactory.Core().V1().ConfigMaps().Lister(), operandAsset)
+
+	old, err := cert.GenerateWithLocalhostServing(time.Now().Add(time.Hour), Organization)
+	if err != nil {
+		t.Fatalf("failed to generate cert: %v", err)
+	}
+	bundle, err := cert.GenerateWithServing(time.Now().Add(90*24*time.Hour), Organization, []string{"localhost"}, runoncedurationoverridev1.KeyAlgorithmRSA3072)
+	if err != nil {
+		t.Fatalf("failed to generate cert: %v", err)
+	}
+	// The CA bundle of a staged rotation holds the old and the new CA.
+	bundle.ServingCertCA = append(append([]byte{}, old.ServingCertCA...), bundle.ServingCertCA...)
+
+	rodoo := createTestRodoo(3600, withCertReadyStatus)
+	reconcileContext := NewReconcileRequestContext(createTestOperandContext())
+	reconcileContext.SetBundle(bundle)
+
+	current, _, err := handler.Handle(reconcileContext, rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status := current.Status.Certificates
+	serving, _ := cert.PEMToCert(bundle.ServiceCert)
+	if status.Serving.SerialNumber != serving.SerialNumber.Text(16) {
+		t.Errorf("expected serving serial %s, got %s", serving.SerialNumber.Text(16), status.Serving.SerialNumber)
+	}
+	if status.Serving.KeyAlgorithm != string(runoncedurationoverridev1.KeyAlgorithmRSA3072) || status.CA.KeyAlgorithm != string(runoncedurationoverridev1.KeyAlgorithmRSA3072) {
+		t.Errorf("expected RSA-3072 keys, got serving=%s ca=%s", status.Serving.KeyAlgorithm, status.CA.KeyAlgorithm)
+	}
+	if len(status.Serving.DNSNames) != 1 || status.Serving.DNSNames[0] != "localhost" {
+		t.Errorf("expected the localhost SAN, got %v", status.Serving.DNSNames)
+	}
+	if status.CA.NotAfter.Before(&metav1.Time{Time: time.Now().Add(89 * 24 * time.Hour)}) {
+		t.Errorf("expected the CA that signed the serving cert, got %+v", status.CA)
+	}
+	if status.LastRotationTime.IsZero() {
+		t.Fatalf("expected LastRotationTime to be set")
+	}
+
+	// The same serving cert is not a rotation.
+	lastRotationTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	current.Status.Certificates.LastRotationTime = lastRotationTime
+	current, _, err = handler.Handle(reconcileContext, current)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !current.Status.Certificates.LastRotationTime.Equal(&lastRotationTime) {
+		t.Errorf("expected LastRotationTime to be kept, got %s", current.Status.Certificates.LastRotationTime)
+	}
+}
+
+func TestCertificateStatusIPAddresses(t *testing.T) {
+	certificate := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+	}
+
+	status := certificateStatus(certificate)
+	if len(status.IPAddresses) != 2 || status.IPAddresses[0] != "127.0.0.1" || status.IPAddresses[1] != "::1" {
+		t.Errorf("expected the IP address SANs, got %v", status.IPAddresses)
+	}
+}
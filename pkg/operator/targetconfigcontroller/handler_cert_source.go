@@ -0,0 +1,58 @@
+package targetconfigcontroller
+
+import (
+	operatorv1 "github.com/openshift/api/operator/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+)
+
+func NewCertSourceSwitchHandler(remover *removalHandler) *certSourceSwitchHandler {
+	return &certSourceSwitchHandler{
+		remover: remover,
+	}
+}
+
+// certSourceSwitchHandler removes the serving cert Secret and the CA bundle
+// ConfigMap when spec.certSource changes, so that the new issuer starts from
+// scratch: the service-ca operator does not take over a Secret it did not
+// create, and a service-ca issued cert is not valid for localhost.
+type certSourceSwitchHandler struct {
+	remover *removalHandler
+}
+
+func (c *certSourceSwitchHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
+	current = original
+
+	desired := original.Spec.GetCertSource()
+	previous := original.Status.CertSource
+	if previous == "" {
+		// Installs that predate spec.certSource use self-signed certs.
+		previous = appsv1.CertSourceSelfSigned
+	}
+
+	if previous != desired {
+		if err := c.remover.RemoveServingCert(); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.CannotRemoveOperand, err)
+			return
+		}
+
+		current.Status.Resources.ServiceCertSecretRef = nil
+		current.Status.Resources.ServiceCAConfigMapRef = nil
+		current.Status.Hash.ServingCert = ""
+		current.Status.CertsRotateAt = metav1.Time{}
+		current.Status.CertRotation.Phase = ""
+		current.Status.Certificates = appsv1.CertificatesStatus{}
+		if previous == appsv1.CertSourceUserProvided {
+			v1helpers.RemoveOperatorCondition(&current.Status.Conditions, operatorv1.OperatorStatusTypeDegraded)
+		}
+
+		klog.V(2).Infof("key=%s switched cert source from %s to %s", original.Name, previous, desired)
+	}
+
+	current.Status.CertSource = desired
+	return
+}
@@ -0,0 +1,111 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+func withCertSource(source runoncedurationoverridev1.CertSource) func(*runoncedurationoverridev1.RunOnceDurationOverride) {
+	return func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+		rodoo.Spec.Workload = runoncedurationoverridev1.WorkloadDeployment
+		rodoo.Spec.CertSource = source
+	}
+}
+
+func TestCertSourceSwitchHandler(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+
+	tests := []struct {
+		name          string
+		previous      runoncedurationoverridev1.CertSource
+		desired       runoncedurationoverridev1.CertSource
+		expectRemoval bool
+	}{
+		{name: "UnsetToSelfSigned", previous: "", desired: runoncedurationoverridev1.CertSourceSelfSigned, expectRemoval: false},
+		{name: "SelfSignedToServiceCA", previous: runoncedurationoverridev1.CertSourceSelfSigned, desired: runoncedurationoverridev1.CertSourceServiceCA, expectRemoval: true},
+		{name: "ServiceCAToSelfSigned", previous: runoncedurationoverridev1.CertSourceServiceCA, desired: runoncedurationoverridev1.CertSourceSelfSigned, expectRemoval: true},
+		{name: "ServiceCA", previous: runoncedurationoverridev1.CertSourceServiceCA, desired: runoncedurationoverridev1.CertSourceServiceCA, expectRemoval: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeKubeClient := kubefake.NewClientset(
+				operandAsset.ServiceServingSecret().New(),
+				operandAsset.CABundleConfigMap().New(),
+			)
+			handler := NewCertSourceSwitchHandler(NewRemovalHandler(fakeKubeClient, events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset))
+
+			rodoo := createTestRodoo(3600, withCertSource(tt.desired))
+			withCertReadyStatus(rodoo)
+			rodoo.Status.CertSource = tt.previous
+
+			current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ctx := context.TODO()
+			_, secretErr := fakeKubeClient.CoreV1().Secrets("test-namespace").Get(ctx, operandAsset.ServiceServingSecret().Name(), metav1.GetOptions{})
+			_, configMapErr := fakeKubeClient.CoreV1().ConfigMaps("test-namespace").Get(ctx, operandAsset.CABundleConfigMap().Name(), metav1.GetOptions{})
+
+			if tt.expectRemoval {
+				if !k8serrors.IsNotFound(secretErr) || !k8serrors.IsNotFound(configMapErr) {
+					t.Errorf("expected serving cert Secret and CA bundle ConfigMap to be removed, got err=%v, %v", secretErr, configMapErr)
+				}
+				if current.Status.Resources.ServiceCertSecretRef != nil || current.Status.Resources.ServiceCAConfigMapRef != nil {
+					t.Errorf("expected serving cert references to be cleared, got %+v", current.Status.Resources)
+				}
+			} else if secretErr != nil || configMapErr != nil {
+				t.Errorf("expected serving cert Secret and CA bundle ConfigMap to be kept, got err=%v, %v", secretErr, configMapErr)
+			}
+
+			if current.Status.CertSource != tt.desired {
+				t.Errorf("expected status certSource %q, got %q", tt.desired, current.Status.CertSource)
+			}
+		})
+	}
+}
+
+func TestServiceHandlerCertSource(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	fakeKubeClient := kubefake.NewClientset()
+	handler := NewServiceHandler(fakeKubeClient, events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset)
+
+	getService := func() *corev1.Service {
+		service, err := fakeKubeClient.CoreV1().Services("test-namespace").Get(context.TODO(), operandAsset.Service().Name(), metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("expected Service to exist: %v", err)
+		}
+		return service
+	}
+
+	rodoo := createTestRodoo(3600, withCertSource(runoncedurationoverridev1.CertSourceServiceCA))
+	current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := getService().Annotations[asset.ServingCertSecretAnnotationName]; got != operandAsset.ServiceServingSecret().Name() {
+		t.Errorf("expected the Service to request serving cert secret %q, got %q", operandAsset.ServiceServingSecret().Name(), got)
+	}
+	if current.Status.Resources.ServiceRef == nil {
+		t.Errorf("expected ServiceRef to be set")
+	}
+
+	current.Spec.CertSource = runoncedurationoverridev1.CertSourceSelfSigned
+	if _, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), current); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := getService().Annotations[asset.ServingCertSecretAnnotationName]; ok {
+		t.Errorf("expected the serving cert secret annotation to be removed")
+	}
+}
@@ -5,22 +5,29 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
 	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	applyfake "github.com/openshift/run-once-duration-override-operator/pkg/apply/fake"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
 	"github.com/openshift/run-once-duration-override-operator/pkg/cert"
 	fakeclientset "github.com/openshift/run-once-duration-override-operator/pkg/generated/clientset/versioned/fake"
 	operatorinformers "github.com/openshift/run-once-duration-override-operator/pkg/generated/informers/externalversions"
@@ -37,6 +44,10 @@ func TestHandlerConditions(t *testing.T) {
 		expectCondition string
 		expectStatus    operatorv1.ConditionStatus
 		expectReason    string
+		// syncs is the number of times the controllers are synced, each sync
+		// picks up what the other controllers wrote in the previous one.
+		// Defaults to 3.
+		syncs int
 	}{
 		// Availability handler conditions
 		{
@@ -68,6 +79,8 @@ func TestHandlerConditions(t *testing.T) {
 			expectCondition: "Available",
 			expectStatus:    operatorv1.ConditionFalse,
 			expectReason:    string(runoncedurationoverridev1.AdmissionWebhookNotAvailable),
+			// Before the DaemonSet is created
+			syncs: 1,
 		},
 
 		// Validation handler conditions
@@ -91,6 +104,106 @@ func TestHandlerConditions(t *testing.T) {
 			expectStatus:    operatorv1.ConditionTrue,
 			expectReason:    string(runoncedurationoverridev1.InvalidParameters),
 		},
+		{
+			name: "ValidationHandler - InvalidNamespaceOverride",
+			rodoo: createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				rodoo.Spec.RunOnceDurationOverrideConfig.Spec.NamespaceOverrides = []runoncedurationoverridev1.NamespaceActiveDeadlineOverride{
+					{
+						NamespaceSelector: metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "team", Operator: "Bogus"},
+							},
+						},
+						ActiveDeadlineSeconds: 600,
+					},
+				}
+			}),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "InstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.InvalidParameters),
+		},
+		{
+			name: "ValidationHandler - InvalidMode",
+			rodoo: createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				rodoo.Spec.RunOnceDurationOverrideConfig.Spec.Mode = "DryRun"
+			}),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "InstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.InvalidParameters),
+		},
+
+		{
+			name: "ValidationHandler - InvalidWebhookTimeout",
+			rodoo: createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				rodoo.Spec.Webhook.TimeoutSeconds = 60
+			}),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "InstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.InvalidParameters),
+		},
+
+		{
+			name: "ValidationHandler - InvalidObjectSelector",
+			rodoo: createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				rodoo.Spec.Webhook.ObjectSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "opt-out", Operator: "Bogus"},
+					},
+				}
+			}),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "InstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.InvalidParameters),
+		},
+		{
+			name: "ValidationHandler - RotationThresholdLongerThanValidity",
+			rodoo: createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				rodoo.Spec.Certificate.Validity = &metav1.Duration{Duration: 72 * time.Hour}
+				rodoo.Spec.Certificate.RotationThreshold = &metav1.Duration{Duration: 96 * time.Hour}
+			}),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "InstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.InvalidParameters),
+		},
+		{
+			name: "ValidationHandler - UserProvidedWithoutSecret",
+			rodoo: createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				rodoo.Spec.CertSource = runoncedurationoverridev1.CertSourceUserProvided
+			}),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "InstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.InvalidParameters),
+		},
+		{
+			name: "ValidationHandler - ServiceCAWithDaemonSet",
+			rodoo: createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				rodoo.Spec.CertSource = runoncedurationoverridev1.CertSourceServiceCA
+			}),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "InstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.InvalidParameters),
+		},
 
 		// Configuration handler conditions - focus on errors
 		{
@@ -116,11 +229,9 @@ func TestHandlerConditions(t *testing.T) {
 			rodoo: createTestRodoo(3600, nil),
 			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
 				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
-				// Only fail when creating the configuration configmap
-				fakeKubeClient.PrependReactor("create", "configmaps", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
-					createAction := action.(kubetesting.CreateAction)
-					obj := createAction.GetObject().(*corev1.ConfigMap)
-					if obj.Name == "test-operator-configuration" {
+				// Only fail when applying the configuration configmap
+				fakeKubeClient.PrependReactor("patch", "configmaps", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+					if action.(kubetesting.PatchAction).GetName() == "test-operator-configuration" {
 						return true, nil, fmt.Errorf("simulated create error")
 					}
 					return false, nil, nil
@@ -138,7 +249,7 @@ func TestHandlerConditions(t *testing.T) {
 			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
 				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
 				// Only fail when getting the server-serving-cert secret
-				// Note: This reactor affects the Get inside ApplySecret, not the lister Get
+				// Note: This reactor affects the Get inside apply.Secret, not the lister Get
 				fakeKubeClient.PrependReactor("get", "secrets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
 					getAction := action.(kubetesting.GetAction)
 					if getAction.GetName() == "server-serving-cert-test-operator" {
@@ -156,11 +267,9 @@ func TestHandlerConditions(t *testing.T) {
 			rodoo: createTestRodoo(3600, nil),
 			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
 				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
-				// Only fail when creating the server-serving-cert secret
-				fakeKubeClient.PrependReactor("create", "secrets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
-					createAction := action.(kubetesting.CreateAction)
-					obj := createAction.GetObject().(*corev1.Secret)
-					if obj.Name == "server-serving-cert-test-operator" {
+				// Only fail when applying the server-serving-cert secret
+				fakeKubeClient.PrependReactor("patch", "secrets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+					if action.(kubetesting.PatchAction).GetName() == "server-serving-cert-test-operator" {
 						return true, nil, fmt.Errorf("simulated create secret error")
 					}
 					return false, nil, nil
@@ -175,11 +284,9 @@ func TestHandlerConditions(t *testing.T) {
 			rodoo: createTestRodoo(3600, nil),
 			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
 				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
-				// Only fail when creating the service-serving configmap
-				fakeKubeClient.PrependReactor("create", "configmaps", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
-					createAction := action.(kubetesting.CreateAction)
-					obj := createAction.GetObject().(*corev1.ConfigMap)
-					if obj.Name == "test-operator-service-serving" {
+				// Only fail when applying the service-serving configmap
+				fakeKubeClient.PrependReactor("patch", "configmaps", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+					if action.(kubetesting.PatchAction).GetName() == "test-operator-service-serving" {
 						return true, nil, fmt.Errorf("simulated create configmap error")
 					}
 					return false, nil, nil
@@ -226,30 +333,51 @@ func TestHandlerConditions(t *testing.T) {
 
 		// WebhookConfigurationHandler conditions
 		{
-			name:  "WebhookConfigurationHandler - GetWebhookError",
+			name:  "WebhookConfigurationHandler - ServingCertMissing",
 			rodoo: createTestRodoo(3600, withWebhookHandlerStatus),
 			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
 				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
-				// Fail when getting webhook configuration
-				fakeKubeClient.PrependReactor("get", "mutatingwebhookconfigurations", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
-					getAction := action.(kubetesting.GetAction)
-					if getAction.GetName() == "runoncedurationoverrides.admission.runoncedurationoverride.openshift.io" {
-						return true, nil, fmt.Errorf("simulated get webhook error")
-					}
-					return false, nil, nil
+				// The serving cert is never issued, the webhook is not registered
+				fakeKubeClient.PrependReactor("patch", "secrets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+					return true, nil, fmt.Errorf("simulated create secret error")
 				})
 			},
-			expectCondition: "InstallReadinessFailure",
+			expectCondition: "WebhookRegistrationInstallReadinessFailure",
 			expectStatus:    operatorv1.ConditionTrue,
-			expectReason:    string(runoncedurationoverridev1.CertNotAvailable),
+			expectReason:    string(runoncedurationoverridev1.AdmissionWebhookNotAvailable),
+		},
+		{
+			name:  "WebhookConfigurationHandler - ServingCertMissing reported by CertManagement",
+			rodoo: createTestRodoo(3600, withWebhookHandlerStatus),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+				fakeKubeClient.PrependReactor("patch", "secrets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+					return true, nil, fmt.Errorf("simulated create secret error")
+				})
+			},
+			expectCondition: "CertManagementInstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.CannotGenerateCert),
+		},
+		{
+			name:  "WebhookConfigurationHandler - ServingCertMissing does not stop the rollout",
+			rodoo: createTestRodoo(3600, withWebhookHandlerStatus),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+				fakeKubeClient.PrependReactor("patch", "secrets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+					return true, nil, fmt.Errorf("simulated create secret error")
+				})
+			},
+			expectCondition: "WorkloadRolloutInstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionFalse,
 		},
 		{
 			name:  "WebhookConfigurationHandler - CreateWebhookError",
 			rodoo: createTestRodoo(3600, withWebhookHandlerStatus),
 			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
 				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
-				// Fail when creating webhook configuration
-				fakeKubeClient.PrependReactor("create", "mutatingwebhookconfigurations", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+				// Fail when applying the webhook configuration
+				fakeKubeClient.PrependReactor("patch", "mutatingwebhookconfigurations", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
 					return true, nil, fmt.Errorf("simulated create webhook error")
 				})
 			},
@@ -257,11 +385,137 @@ func TestHandlerConditions(t *testing.T) {
 			expectStatus:    operatorv1.ConditionTrue,
 			expectReason:    string(runoncedurationoverridev1.CertNotAvailable),
 		},
+
+		// Management state
+		{
+			name:  "ManagementState - Unmanaged",
+			rodoo: createTestRodoo(3600, withManagementState(operatorv1.Unmanaged)),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				// The Managed chain would fail here; Unmanaged must not touch the operand
+				fakeKubeClient.PrependReactor("patch", "configmaps", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+					return true, nil, fmt.Errorf("simulated create configmap error")
+				})
+			},
+			expectCondition: "Available",
+			expectStatus:    operatorv1.ConditionFalse,
+			expectReason:    string(runoncedurationoverridev1.AdmissionWebhookNotAvailable),
+		},
+		{
+			name:  "ManagementState - Removed",
+			rodoo: createTestRodoo(3600, withManagementState(operatorv1.Removed)),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "Available",
+			expectStatus:    operatorv1.ConditionFalse,
+			expectReason:    string(runoncedurationoverridev1.OperandRemoved),
+		},
+		{
+			name:  "ManagementState - RemovalError",
+			rodoo: createTestRodoo(3600, withManagementState(operatorv1.Removed)),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+				fakeKubeClient.PrependReactor("delete", "daemonsets", func(action kubetesting.Action) (handled bool, ret runtime.Object, err error) {
+					return true, nil, fmt.Errorf("simulated delete daemonset error")
+				})
+			},
+			expectCondition: "InstallReadinessFailure",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.CannotRemoveOperand),
+		},
+		{
+			name:  "DeploymentMode - AdmissionPolicy",
+			rodoo: createTestRodoo(3600, withDeploymentMode(runoncedurationoverridev1.DeploymentModeAdmissionPolicy)),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				fakeKubeClient.Resources = []*metav1.APIResourceList{
+					{
+						GroupVersion: "admissionregistration.k8s.io/v1beta1",
+						APIResources: []metav1.APIResource{{Name: "mutatingadmissionpolicies"}, {Name: "mutatingadmissionpolicybindings"}},
+					},
+				}
+			},
+			expectCondition: "DeploymentModeFallback",
+			expectStatus:    operatorv1.ConditionFalse,
+			expectReason:    string(runoncedurationoverridev1.AsExpected),
+		},
+		{
+			name:  "DeploymentMode - AdmissionPolicyNotServed",
+			rodoo: createTestRodoo(3600, withDeploymentMode(runoncedurationoverridev1.DeploymentModeAdmissionPolicy)),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "DeploymentModeFallback",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.AdmissionPolicyNotServed),
+		},
+
+		// Standard conditions
+		{
+			name:  "Progressing - RollingOut",
+			rodoo: createTestRodoo(3600, withManagementState(operatorv1.Unmanaged)),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createNotReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "Progressing",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.RollingOut),
+		},
+		{
+			name:  "Progressing - ConfigurationChanged",
+			rodoo: createTestRodoo(3600, nil),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				// The first reconcile computes the configuration hash.
+			},
+			expectCondition: "Progressing",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.ConfigurationChanged),
+			syncs:           1,
+		},
+		{
+			name:  "Degraded - WithinGracePeriod",
+			rodoo: createTestRodoo(-1, nil),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "Degraded",
+			expectStatus:    operatorv1.ConditionFalse,
+			expectReason:    string(runoncedurationoverridev1.AsExpected),
+		},
+		{
+			name: "Degraded - AfterGracePeriod",
+			rodoo: createTestRodoo(-1, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				// The configuration has been failing for longer than the grace period.
+				rodoo.Status.Conditions = []operatorv1.OperatorCondition{
+					{
+						Type:               "ConfigurationInstallReadinessFailure",
+						Status:             operatorv1.ConditionTrue,
+						Reason:             string(runoncedurationoverridev1.InvalidParameters),
+						LastTransitionTime: metav1.NewTime(time.Now().Add(-degradedGracePeriod - time.Minute)),
+					},
+				}
+			}),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "Degraded",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.InvalidParameters),
+		},
+		{
+			name:  "Upgradeable",
+			rodoo: createTestRodoo(3600, nil),
+			setupFunc: func(fakeKubeClient *kubefake.Clientset) {
+				createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+			},
+			expectCondition: "Upgradeable",
+			expectStatus:    operatorv1.ConditionTrue,
+			expectReason:    string(runoncedurationoverridev1.AsExpected),
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fakeKubeClient := kubefake.NewSimpleClientset()
+			fakeKubeClient := kubefake.NewClientset()
 			fakeOperatorClient := fakeclientset.NewSimpleClientset(tt.rodoo)
 
 			tt.setupFunc(fakeKubeClient)
@@ -281,17 +535,19 @@ func TestHandlerConditions(t *testing.T) {
 			defer cancel()
 
 			// Create controller first - this registers informers via factory.New().WithFilteredEventsInformers
-			c := NewTargetConfigController(
+			controllers := NewTargetConfigControllers(
 				&operatorclient.RunOnceDurationOverrideClient{
 					Ctx:                             ctx,
 					RunOnceDurationOverrideInformer: operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides(),
 					OperatorClient:                  fakeOperatorClient.RunOnceDurationOverrideV1(),
 				},
 				fakeKubeClient,
+				applyfake.NewSimpleDynamicClient(runtime.NewScheme()),
 				createTestOperandContext(),
 				kubeInformerFactory,
 				operatorInformerFactory,
 				events.NewLoggingEventRecorder("test-operator", clock.RealClock{}),
+				&record.FakeRecorder{},
 			)
 
 			// Start informers and wait for caches to sync
@@ -301,9 +557,18 @@ func TestHandlerConditions(t *testing.T) {
 			operatorInformerFactory.WaitForCacheSync(ctx.Done())
 
 			// Call Sync directly instead of Run
-			err := c.Sync(ctx, &fakeSyncContext{recorder: events.NewLoggingEventRecorder("test", clock.RealClock{})})
-			if err != nil {
-				t.Logf("Sync returned error (may be expected): %v", err)
+			syncs := tt.syncs
+			if syncs == 0 {
+				syncs = 3
+			}
+			for i := 0; i < syncs; i++ {
+				if i > 0 {
+					// Give watch events time to propagate to informers
+					time.Sleep(50 * time.Millisecond)
+				}
+				if err := syncControllers(ctx, controllers); err != nil {
+					t.Logf("Sync returned error (may be expected): %v", err)
+				}
 			}
 
 			// Get the updated status
@@ -319,6 +584,18 @@ func TestHandlerConditions(t *testing.T) {
 
 // Helper functions
 
+// syncControllers runs a sync of each of the given controllers, in order.
+func syncControllers(ctx context.Context, controllers []factory.Controller) error {
+	var errs []error
+	for _, c := range controllers {
+		if err := c.Sync(ctx, &fakeSyncContext{recorder: events.NewLoggingEventRecorder("test", clock.RealClock{})}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
 func createTestRodoo(activeDeadlineSeconds int64, applyFunc func(*runoncedurationoverridev1.RunOnceDurationOverride)) *runoncedurationoverridev1.RunOnceDurationOverride {
 	rodoo := &runoncedurationoverridev1.RunOnceDurationOverride{
 		ObjectMeta: metav1.ObjectMeta{
@@ -342,6 +619,18 @@ func createTestOperandContext() operatorruntime.OperandContext {
 	return operatorruntime.NewOperandContext("test-operator", "test-namespace", "cluster", "test-image:latest", "v1.0.0")
 }
 
+func withManagementState(state operatorv1.ManagementState) func(*runoncedurationoverridev1.RunOnceDurationOverride) {
+	return func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+		rodoo.Spec.ManagementState = state
+	}
+}
+
+func withDeploymentMode(mode runoncedurationoverridev1.DeploymentMode) func(*runoncedurationoverridev1.RunOnceDurationOverride) {
+	return func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+		rodoo.Spec.DeploymentMode = mode
+	}
+}
+
 func withCertReadyStatus(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
 	rodoo.Status.Resources.ConfigurationRef = &corev1.ObjectReference{
 		Name:            "test-operator-configuration",
@@ -447,6 +736,135 @@ func (f *fakeSyncContext) QueueKey() string {
 	return ""
 }
 
+// TestMergeStatus checks that the status written by sync keeps the conditions
+// other controllers set since the object was read.
+func TestMergeStatus(t *testing.T) {
+	original := &runoncedurationoverridev1.RunOnceDurationOverrideStatus{}
+	original.Conditions = []operatorv1.OperatorCondition{
+		{Type: "Available", Status: operatorv1.ConditionFalse},
+		{Type: "Stale", Status: operatorv1.ConditionTrue},
+		{Type: "ConfigObservationDegraded", Status: operatorv1.ConditionFalse},
+	}
+
+	latest := original.DeepCopy()
+	v1helpers.SetOperatorCondition(&latest.Conditions, operatorv1.OperatorCondition{Type: "ConfigObservationDegraded", Status: operatorv1.ConditionTrue, Reason: "Error"})
+
+	desired := original.DeepCopy()
+	desired.Hash.Configuration = "new-hash"
+	v1helpers.SetOperatorCondition(&desired.Conditions, operatorv1.OperatorCondition{Type: "Available", Status: operatorv1.ConditionTrue})
+	v1helpers.RemoveOperatorCondition(&desired.Conditions, "Stale")
+
+	if err := mergeStatus(latest, original, desired); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if latest.Hash.Configuration != "new-hash" {
+		t.Errorf("expected the hash computed by sync, got %q", latest.Hash.Configuration)
+	}
+	if cond := findCondition(latest.Conditions, "Available"); cond == nil || cond.Status != operatorv1.ConditionTrue {
+		t.Errorf("expected Available=True, got %v", cond)
+	}
+	if cond := findCondition(latest.Conditions, "Stale"); cond != nil {
+		t.Errorf("expected Stale to be removed, got %v", cond)
+	}
+	if cond := findCondition(latest.Conditions, "ConfigObservationDegraded"); cond == nil || cond.Status != operatorv1.ConditionTrue {
+		t.Errorf("expected the condition set by another controller to be kept, got %v", cond)
+	}
+}
+
+// TestSetOperatorConditions checks that the operator conditions are the union
+// of the conditions reported by the areas.
+func TestSetOperatorConditions(t *testing.T) {
+	status := &runoncedurationoverridev1.RunOnceDurationOverrideStatus{}
+	status.Conditions = []operatorv1.OperatorCondition{
+		{Type: ConfigurationConditionPrefix + "Available", Status: operatorv1.ConditionTrue, Reason: "AsExpected"},
+		{Type: WorkloadRolloutConditionPrefix + "Available", Status: operatorv1.ConditionFalse, Reason: "DeploymentNotReady", Message: "not ready"},
+		{Type: ConfigurationConditionPrefix + "Degraded", Status: operatorv1.ConditionTrue, Reason: "ConfigurationCheckFailed", Message: "invalid"},
+		{Type: CertManagementConditionPrefix + "Degraded", Status: operatorv1.ConditionTrue, Reason: "CertNotAvailable", Message: "no cert"},
+		{Type: WebhookRegistrationConditionPrefix + "Degraded", Status: operatorv1.ConditionFalse, Reason: "AsExpected"},
+		{Type: ConfigurationConditionPrefix + "Upgradeable", Status: operatorv1.ConditionTrue, Reason: "AsExpected"},
+	}
+
+	setOperatorConditions(status, conditionPrefixes)
+
+	if cond := findCondition(status.Conditions, "Available"); cond == nil || cond.Status != operatorv1.ConditionFalse || cond.Reason != "DeploymentNotReady" {
+		t.Errorf("expected Available=False from the workload rollout, got %v", cond)
+	}
+	cond := findCondition(status.Conditions, "Degraded")
+	if cond == nil || cond.Status != operatorv1.ConditionTrue || cond.Reason != "ConfigurationCheckFailed" {
+		t.Fatalf("expected Degraded=True from the configuration, got %v", cond)
+	}
+	if expected := "Configuration: invalid\nCertManagement: no cert"; cond.Message != expected {
+		t.Errorf("expected message %q, got %q", expected, cond.Message)
+	}
+	if cond := findCondition(status.Conditions, "Upgradeable"); cond == nil || cond.Status != operatorv1.ConditionTrue {
+		t.Errorf("expected Upgradeable=True, got %v", cond)
+	}
+	if cond := findCondition(status.Conditions, "Progressing"); cond != nil {
+		t.Errorf("expected no Progressing condition without an area reporting it, got %v", cond)
+	}
+}
+
+// TestConcurrentSync runs sync from several workers at once. The workqueue does
+// not hand the same key to two workers, sync must be safe regardless.
+func TestConcurrentSync(t *testing.T) {
+	fakeKubeClient := kubefake.NewClientset()
+	createReadyDaemonSet(fakeKubeClient, "test-operator", "test-namespace")
+	fakeOperatorClient := fakeclientset.NewSimpleClientset(createTestRodoo(3600, nil))
+
+	kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(fakeKubeClient, 0, informers.WithNamespace("test-namespace"))
+	operatorInformerFactory := operatorinformers.NewSharedInformerFactory(fakeOperatorClient, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	controllers := NewTargetConfigControllers(
+		&operatorclient.RunOnceDurationOverrideClient{
+			Ctx:                             ctx,
+			RunOnceDurationOverrideInformer: operatorInformerFactory.RunOnceDurationOverride().V1().RunOnceDurationOverrides(),
+			OperatorClient:                  fakeOperatorClient.RunOnceDurationOverrideV1(),
+		},
+		fakeKubeClient,
+		applyfake.NewSimpleDynamicClient(runtime.NewScheme()),
+		createTestOperandContext(),
+		kubeInformerFactory,
+		operatorInformerFactory,
+		events.NewLoggingEventRecorder("test-operator", clock.RealClock{}),
+		&record.FakeRecorder{},
+	)
+
+	kubeInformerFactory.Start(ctx.Done())
+	operatorInformerFactory.Start(ctx.Done())
+	kubeInformerFactory.WaitForCacheSync(ctx.Done())
+	operatorInformerFactory.WaitForCacheSync(ctx.Done())
+
+	const workers = 4
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := syncControllers(ctx, controllers); err != nil {
+				t.Logf("Sync returned error (may be expected): %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	updated, err := fakeOperatorClient.RunOnceDurationOverrideV1().RunOnceDurationOverrides().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get updated CR: %v", err)
+	}
+	for _, conditionType := range []string{"Available", "Progressing", "Degraded", "Upgradeable"} {
+		if findCondition(updated.Status.Conditions, conditionType) == nil {
+			t.Errorf("expected condition %s, got %+v", conditionType, updated.Status.Conditions)
+		}
+	}
+	if updated.Status.Hash.Configuration == "" {
+		t.Errorf("expected the configuration hash to be set")
+	}
+}
+
 // TestCertReadyHandler tests the CertReadyHandler in isolation
 func TestCertReadyHandler(t *testing.T) {
 	tests := []struct {
@@ -600,7 +1018,7 @@ func TestCertReadyHandler(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create fake client without pre-populated objects
-			fakeKubeClient := kubefake.NewSimpleClientset()
+			fakeKubeClient := kubefake.NewClientset()
 
 			// Create informer factory with namespace filtering
 			kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(
@@ -636,6 +1054,7 @@ func TestCertReadyHandler(t *testing.T) {
 				fakeKubeClient,
 				kubeInformerFactory.Core().V1().Secrets().Lister(),
 				kubeInformerFactory.Core().V1().ConfigMaps().Lister(),
+				asset.New(createTestOperandContext()),
 			)
 
 			// Create reconcile context
@@ -13,9 +13,9 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/openshift/library-go/pkg/operator/events"
-	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
 	"github.com/openshift/run-once-duration-override-operator/pkg/apis/reference"
 	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
 	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
 )
 
@@ -52,7 +52,7 @@ func (c *configurationHandler) Handle(context *ReconcileRequestContext, original
 			return
 		}
 
-		cm, _, err := resourceapply.ApplyConfigMap(gocontext.TODO(), c.client.CoreV1(), c.recorder, desired)
+		cm, _, err := apply.ConfigMap(gocontext.TODO(), c.client.CoreV1(), c.recorder, desired)
 		if err != nil {
 			handleErr = NewInstallReadinessError(appsv1.InternalError, err)
 			return
@@ -76,7 +76,7 @@ func (c *configurationHandler) Handle(context *ReconcileRequestContext, original
 	if !equal {
 		klog.V(2).Infof("key=%s resource=%T/%s configuration has drifted", original.Name, object, object.Name)
 
-		cm, _, err := resourceapply.ApplyConfigMap(gocontext.TODO(), c.client.CoreV1(), c.recorder, desired)
+		cm, _, err := apply.ConfigMap(gocontext.TODO(), c.client.CoreV1(), c.recorder, desired)
 		if err != nil {
 			handleErr = NewInstallReadinessError(appsv1.ConfigurationCheckFailed, err)
 			return
@@ -0,0 +1,94 @@
+package targetconfigcontroller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+func withNamespaceOverrides(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+	rodoo.Spec.RunOnceDurationOverrideConfig.Spec.NamespaceOverrides = []runoncedurationoverridev1.NamespaceActiveDeadlineOverride{
+		{
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"workload": "batch"},
+			},
+			ActiveDeadlineSeconds: 86400,
+		},
+		{
+			NamespaceSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "ci", Operator: metav1.LabelSelectorOpExists},
+				},
+			},
+			ActiveDeadlineSeconds: 1800,
+		},
+	}
+}
+
+func TestConfigurationHandlerNewConfiguration(t *testing.T) {
+	fakeKubeClient := kubefake.NewClientset()
+	kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+
+	handler := NewConfigurationHandler(
+		fakeKubeClient,
+		events.NewLoggingEventRecorder("test", clock.RealClock{}),
+		kubeInformerFactory.Core().V1().ConfigMaps().Lister(),
+		asset.New(createTestOperandContext()),
+	)
+
+	rodoo := createTestRodoo(3600, withNamespaceOverrides)
+	configuration, err := handler.NewConfiguration(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, ok := configuration.Data[handler.asset.Values().ConfigurationKey]
+	if !ok {
+		t.Fatalf("expected configuration key %q to be set", handler.asset.Values().ConfigurationKey)
+	}
+
+	observed := runoncedurationoverridev1.RunOnceDurationOverrideConfig{}
+	if err := yaml.Unmarshal([]byte(data), &observed); err != nil {
+		t.Fatalf("failed to unmarshal configuration: %v", err)
+	}
+
+	want := rodoo.Spec.RunOnceDurationOverrideConfig.Spec
+	if observed.Spec.Hash() != want.Hash() {
+		t.Errorf("expected configuration %q, got %q", want.String(), observed.Spec.String())
+	}
+	if len(observed.Spec.NamespaceOverrides) != 2 {
+		t.Fatalf("expected 2 namespace overrides, got %d", len(observed.Spec.NamespaceOverrides))
+	}
+}
+
+func TestConfigurationSpecHashCoversNamespaceOverrides(t *testing.T) {
+	plain := createTestRodoo(3600, nil).Spec.RunOnceDurationOverrideConfig.Spec
+	withRules := createTestRodoo(3600, withNamespaceOverrides).Spec.RunOnceDurationOverrideConfig.Spec
+
+	if plain.String() != "ActiveDeadlineSeconds=3600" {
+		t.Errorf("expected String() without rules to be unchanged, got %q", plain.String())
+	}
+	if plain.Hash() == withRules.Hash() {
+		t.Errorf("expected hash to change when namespace overrides are added")
+	}
+
+	changed := withRules.DeepCopy()
+	changed.NamespaceOverrides[1].ActiveDeadlineSeconds = 900
+	if changed.Hash() == withRules.Hash() {
+		t.Errorf("expected hash to change when a rule deadline changes")
+	}
+
+	invalid := withRules.DeepCopy()
+	invalid.NamespaceOverrides[0].ActiveDeadlineSeconds = -1
+	if err := invalid.Validate(); err == nil {
+		t.Errorf("expected validation error for negative rule deadline")
+	}
+}
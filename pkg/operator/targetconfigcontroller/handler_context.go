@@ -1,6 +1,8 @@
 package targetconfigcontroller
 
 import (
+	"k8s.io/client-go/tools/record"
+
 	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
 	"github.com/openshift/run-once-duration-override-operator/pkg/cert"
 	operatorruntime "github.com/openshift/run-once-duration-override-operator/pkg/runtime"
@@ -30,9 +32,30 @@ func NewReconcileRequestContext(oc operatorruntime.OperandContext) *ReconcileReq
 	}
 }
 
+// ReconcileRequestContext carries the state of a single run of the handler
+// chain, such as the serving cert bundle. Handlers are shared by the workers of
+// the controller and keep no state of their own, every sync creates its own
+// context and never shares it with another worker.
 type ReconcileRequestContext struct {
 	operatorruntime.OperandContext
-	bundle *cert.Bundle
+	bundle   *cert.Bundle
+	recorder record.EventRecorder
+}
+
+// WithRecorder sets the recorder of Events on the RunOnceDurationOverride object.
+func (r *ReconcileRequestContext) WithRecorder(recorder record.EventRecorder) *ReconcileRequestContext {
+	r.recorder = recorder
+	return r
+}
+
+// Recorder returns the recorder of Events on the RunOnceDurationOverride object.
+// Events are dropped if no recorder is set.
+func (r *ReconcileRequestContext) Recorder() record.EventRecorder {
+	if r.recorder == nil {
+		return &record.FakeRecorder{}
+	}
+
+	return r.recorder
 }
 
 func (r *ReconcileRequestContext) SetBundle(bundle *cert.Bundle) {
@@ -11,6 +11,7 @@ import (
 	k8sappsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -19,12 +20,13 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/openshift/library-go/pkg/operator/events"
-	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
 	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
 	"github.com/openshift/run-once-duration-override-operator/pkg/apis/reference"
 	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
 	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
 	"github.com/openshift/run-once-duration-override-operator/pkg/deploy"
+	"github.com/openshift/run-once-duration-override-operator/pkg/operator/loglevel"
 	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -50,7 +52,8 @@ type Deployer interface {
 
 func (c *daemonSetHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
 	current = original
-	ensure := false
+	// cause is why the workload is rolled out, empty if it is up to date.
+	cause := ""
 
 	object, accessor, getErr := c.deploy.Get()
 	if getErr != nil && !k8serrors.IsNotFound(getErr) {
@@ -68,31 +71,53 @@ func (c *daemonSetHandler) Handle(context *ReconcileRequestContext, original *ap
 	}
 	current.Status.Hash.ObservedConfig = observedConfigHash
 
+	placementHash, hashErr := operandPlacementHash(original.Spec.OperandPlacement)
+	if hashErr != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, hashErr)
+		return
+	}
+	current.Status.Hash.OperandPlacement = placementHash
+
 	switch {
 	case k8serrors.IsNotFound(getErr):
-		ensure = true
+		cause = "created"
 	case accessor.GetAnnotations()[values.ConfigurationHashAnnotationKey] != current.Status.Hash.Configuration:
 		klog.V(2).Infof("key=%s resource=%T/%s configuration hash mismatch", original.Name, object, accessor.GetName())
-		ensure = true
+		cause = "configuration changed"
 	case accessor.GetAnnotations()[values.ServingCertHashAnnotationKey] != current.Status.Hash.ServingCert:
 		klog.V(2).Infof("key=%s resource=%T/%s serving cert hash mismatch", original.Name, object, accessor.GetName())
-		ensure = true
+		cause = "serving cert changed"
 	case accessor.GetAnnotations()[values.ObservedConfigHashAnnotationKey] != observedConfigHash:
 		klog.V(2).Infof("key=%s resource=%T/%s observed config hash mismatch", original.Name, object, accessor.GetName())
-		ensure = true
-	case values.OperandImage != object.(*k8sappsv1.DaemonSet).Spec.Template.Spec.Containers[0].Image:
+		cause = "observed config changed"
+	case accessor.GetAnnotations()[values.PlacementHashAnnotationKey] != placementHash:
+		klog.V(2).Infof("key=%s resource=%T/%s operand placement hash mismatch", original.Name, object, accessor.GetName())
+		cause = "operand placement changed"
+	case accessor.GetAnnotations()[values.LogLevelAnnotationKey] != string(original.Spec.LogLevel):
+		klog.V(2).Infof("key=%s resource=%T/%s log level mismatch", original.Name, object, accessor.GetName())
+		cause = "log level changed"
+	case imageMismatch(object, values.OperandImage):
 		klog.V(2).Infof("key=%s resource=%T/%s container image mismatch", original.Name, object, accessor.GetName())
-		ensure = true
+		cause = "operand image changed"
+	case replicasMismatch(object, original.Spec.GetReplicas()):
+		klog.V(2).Infof("key=%s resource=%T/%s replicas mismatch", original.Name, object, accessor.GetName())
+		cause = "replicas changed"
 	}
 
-	if ensure {
+	if cause != "" {
 		object, accessor, handleErr = c.Ensure(context, original)
 		if handleErr != nil {
 			return
 		}
 
-		resourcemerge.SetDaemonSetGeneration(&current.Status.Generations, object.(*k8sappsv1.DaemonSet))
+		switch workload := object.(type) {
+		case *k8sappsv1.DaemonSet:
+			resourcemerge.SetDaemonSetGeneration(&current.Status.Generations, workload)
+		case *k8sappsv1.Deployment:
+			resourcemerge.SetDeploymentGeneration(&current.Status.Generations, workload)
+		}
 		klog.V(2).Infof("key=%s resource=%T/%s successfully ensured", original.Name, object, accessor.GetName())
+		context.Recorder().Eventf(original, corev1.EventTypeNormal, "OperandRollout", "Rolling out %s %s/%s: %s", original.Spec.GetWorkload(), accessor.GetNamespace(), accessor.GetName(), cause)
 	}
 
 	if ref := current.Status.Resources.DeploymentRef; ref != nil && ref.ResourceVersion == accessor.GetResourceVersion() {
@@ -112,13 +137,12 @@ func (c *daemonSetHandler) Handle(context *ReconcileRequestContext, original *ap
 	return
 }
 
+// Ensure applies the RBAC and the workload. The MutatingWebhookConfiguration is
+// left registered while the workload rolls its pods out. The Deployment keeps
+// admission served throughout, the DaemonSet does not: the API server on the
+// node whose pod is being replaced fails its webhook calls until the new pod is
+// ready.
 func (c *daemonSetHandler) Ensure(ctx *ReconcileRequestContext, cro *appsv1.RunOnceDurationOverride) (current runtime.Object, accessor metav1.Object, err error) {
-	name := c.asset.NewMutatingWebhookConfiguration().Name()
-	if deleteErr := c.client.AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(context.TODO(), name, metav1.DeleteOptions{}); deleteErr != nil && !k8serrors.IsNotFound(deleteErr) {
-		err = fmt.Errorf("failed to delete MutatingWebhookConfiguration - %s", deleteErr.Error())
-		return
-	}
-
 	if err = c.EnsureRBAC(ctx, cro); err != nil {
 		return
 	}
@@ -140,11 +164,65 @@ func (c *daemonSetHandler) ApplyToDeploymentObject(context *ReconcileRequestCont
 		object.GetAnnotations()[values.ConfigurationHashAnnotationKey] = cro.Status.Hash.Configuration
 		object.GetAnnotations()[values.ServingCertHashAnnotationKey] = cro.Status.Hash.ServingCert
 		object.GetAnnotations()[values.ObservedConfigHashAnnotationKey] = cro.Status.Hash.ObservedConfig
+		object.GetAnnotations()[values.PlacementHashAnnotationKey] = cro.Status.Hash.OperandPlacement
+		object.GetAnnotations()[values.LogLevelAnnotationKey] = string(cro.Spec.LogLevel)
+
+		if deployment, ok := object.(*k8sappsv1.Deployment); ok {
+			replicas := cro.Spec.GetReplicas()
+			deployment.Spec.Replicas = &replicas
+		}
 
 		context.ControllerSetter().Set(object, cro)
 	}
 }
 
+// operandPlacementHash returns the hash of the given operand placement, empty
+// when none of its fields is set so that the workload of an install that does
+// not use it is not rolled out.
+func operandPlacementHash(placement appsv1.OperandPlacement) (string, error) {
+	if equality.Semantic.DeepEqual(placement, appsv1.OperandPlacement{}) {
+		return "", nil
+	}
+
+	data, err := json.Marshal(placement)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash the operand placement - %s", err.Error())
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// replicasMismatch returns true if the given object is a Deployment that does
+// not run the desired number of replicas.
+func replicasMismatch(object runtime.Object, replicas int32) bool {
+	deployment, ok := object.(*k8sappsv1.Deployment)
+	if !ok {
+		return false
+	}
+
+	return deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != replicas
+}
+
+// imageMismatch returns true if the webhook server container of the given
+// workload object does not run the given image.
+func imageMismatch(object runtime.Object, image string) bool {
+	containers := podTemplateOf(object).Spec.Containers
+	return len(containers) == 0 || containers[0].Image != image
+}
+
+// podTemplateOf returns the pod template of the given workload object.
+func podTemplateOf(object runtime.Object) *corev1.PodTemplateSpec {
+	switch workload := object.(type) {
+	case *k8sappsv1.DaemonSet:
+		return &workload.Spec.Template
+	case *k8sappsv1.Deployment:
+		return &workload.Spec.Template
+	}
+
+	return &corev1.PodTemplateSpec{}
+}
+
 func (c *daemonSetHandler) ApplyToToPodTemplate(context *ReconcileRequestContext, cro *appsv1.RunOnceDurationOverride) deploy.Applier {
 	values := c.asset.Values()
 
@@ -156,6 +234,12 @@ func (c *daemonSetHandler) ApplyToToPodTemplate(context *ReconcileRequestContext
 		object.GetAnnotations()[values.OwnerAnnotationKey] = cro.Name
 		object.GetAnnotations()[values.ConfigurationHashAnnotationKey] = cro.Status.Hash.Configuration
 		object.GetAnnotations()[values.ServingCertHashAnnotationKey] = cro.Status.Hash.ServingCert
+		// The pod template of a webhook server installed before spec.logLevel
+		// existed has no log level annotation, leave it out while the level is
+		// unset so that upgrading does not roll the pods out.
+		if cro.Spec.LogLevel != "" {
+			object.GetAnnotations()[values.LogLevelAnnotationKey] = string(cro.Spec.LogLevel)
+		}
 
 		podTemplate, ok := object.(*corev1.PodTemplateSpec)
 		if !ok {
@@ -163,6 +247,28 @@ func (c *daemonSetHandler) ApplyToToPodTemplate(context *ReconcileRequestContext
 			return
 		}
 
+		if len(podTemplate.Spec.Containers) > 0 {
+			container := &podTemplate.Spec.Containers[0]
+
+			// Replace the klog verbosity with the one derived from spec.logLevel
+			filteredArgs := []string{}
+			for _, arg := range container.Args {
+				if !strings.HasPrefix(arg, "--v=") {
+					filteredArgs = append(filteredArgs, arg)
+				}
+			}
+			container.Args = append(filteredArgs, fmt.Sprintf("--v=%d", loglevel.OperandLogLevelToVerbosity(cro.Spec.LogLevel)))
+		}
+
+		applyOperandPlacement(&podTemplate.Spec, cro.Spec.OperandPlacement)
+
+		// Mount the serving cert from the Secret of the cert source in use.
+		for i := range podTemplate.Spec.Volumes {
+			if secret := podTemplate.Spec.Volumes[i].Secret; secret != nil && secret.SecretName == c.asset.ServiceServingSecret().Name() {
+				secret.SecretName = servingCertSecretName(c.asset, cro)
+			}
+		}
+
 		var observedConfig map[string]interface{}
 		if len(cro.Spec.ObservedConfig.Raw) > 0 {
 			if err := json.Unmarshal(cro.Spec.ObservedConfig.Raw, &observedConfig); err != nil {
@@ -217,19 +323,19 @@ func (c *daemonSetHandler) EnsureRBAC(reqContext *ReconcileRequestContext, in *a
 		ctx := context.TODO()
 		switch obj := item.Object.(type) {
 		case *corev1.ServiceAccount:
-			_, _, err = resourceapply.ApplyServiceAccount(ctx, c.client.CoreV1(), c.recorder, obj)
+			_, _, err = apply.ServiceAccount(ctx, c.client.CoreV1(), c.recorder, obj)
 			name = obj.Name
 		case *rbacv1.Role:
-			_, _, err = resourceapply.ApplyRole(ctx, c.client.RbacV1(), c.recorder, obj)
+			_, _, err = apply.Role(ctx, c.client.RbacV1(), c.recorder, obj)
 			name = obj.Name
 		case *rbacv1.RoleBinding:
-			_, _, err = resourceapply.ApplyRoleBinding(ctx, c.client.RbacV1(), c.recorder, obj)
+			_, _, err = apply.RoleBinding(ctx, c.client.RbacV1(), c.recorder, obj)
 			name = obj.Name
 		case *rbacv1.ClusterRole:
-			_, _, err = resourceapply.ApplyClusterRole(ctx, c.client.RbacV1(), c.recorder, obj)
+			_, _, err = apply.ClusterRole(ctx, c.client.RbacV1(), c.recorder, obj)
 			name = obj.Name
 		case *rbacv1.ClusterRoleBinding:
-			_, _, err = resourceapply.ApplyClusterRoleBinding(ctx, c.client.RbacV1(), c.recorder, obj)
+			_, _, err = apply.ClusterRoleBinding(ctx, c.client.RbacV1(), c.recorder, obj)
 			name = obj.Name
 		default:
 			return fmt.Errorf("unsupported RBAC resource type: %T", item.Object)
@@ -244,3 +350,21 @@ func (c *daemonSetHandler) EnsureRBAC(reqContext *ReconcileRequestContext, in *a
 
 	return nil
 }
+
+// applyOperandPlacement replaces the scheduling settings and the container
+// resources of the given pod spec with the ones of the operand placement that
+// are set.
+func applyOperandPlacement(spec *corev1.PodSpec, placement appsv1.OperandPlacement) {
+	if len(placement.NodeSelector) > 0 {
+		spec.NodeSelector = placement.NodeSelector
+	}
+	if len(placement.Tolerations) > 0 {
+		spec.Tolerations = placement.Tolerations
+	}
+	if placement.PriorityClassName != "" {
+		spec.PriorityClassName = placement.PriorityClassName
+	}
+	if len(spec.Containers) > 0 {
+		spec.Containers[0].Resources = placement.Resources
+	}
+}
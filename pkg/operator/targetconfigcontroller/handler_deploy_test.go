@@ -0,0 +1,209 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	k8sadmissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+	"github.com/openshift/run-once-duration-override-operator/pkg/deploy"
+)
+
+func TestDaemonSetHandlerLogLevel(t *testing.T) {
+	tests := []struct {
+		logLevel operatorv1.LogLevel
+		expected string
+	}{
+		{logLevel: "", expected: "--v=3"},
+		{logLevel: operatorv1.Normal, expected: "--v=2"},
+		{logLevel: operatorv1.Debug, expected: "--v=4"},
+		{logLevel: operatorv1.Trace, expected: "--v=6"},
+		{logLevel: operatorv1.TraceAll, expected: "--v=8"},
+	}
+
+	operandAsset := asset.New(createTestOperandContext())
+	handler := NewDaemonSetHandler(kubefake.NewClientset(), events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset, nil)
+
+	for _, tt := range tests {
+		t.Run(string(tt.logLevel), func(t *testing.T) {
+			rodoo := createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				rodoo.Spec.LogLevel = tt.logLevel
+			})
+
+			desired := operandAsset.DaemonSet().New()
+			// Simulate a stale verbosity left over from a previous level.
+			desired.Spec.Template.Spec.Containers[0].Args = append(desired.Spec.Template.Spec.Containers[0].Args, "--v=9")
+
+			context := NewReconcileRequestContext(createTestOperandContext())
+			handler.ApplyToDeploymentObject(context, rodoo).Apply(desired)
+			handler.ApplyToToPodTemplate(context, rodoo).Apply(&desired.Spec.Template)
+
+			var verbosity []string
+			for _, arg := range desired.Spec.Template.Spec.Containers[0].Args {
+				if strings.HasPrefix(arg, "--v=") {
+					verbosity = append(verbosity, arg)
+				}
+			}
+			if len(verbosity) != 1 || verbosity[0] != tt.expected {
+				t.Errorf("expected a single %q arg, got %v", tt.expected, verbosity)
+			}
+
+			key := operandAsset.Values().LogLevelAnnotationKey
+			if got := desired.GetAnnotations()[key]; got != string(tt.logLevel) {
+				t.Errorf("expected DaemonSet annotation %s=%q, got %q", key, tt.logLevel, got)
+			}
+			got, ok := desired.Spec.Template.GetAnnotations()[key]
+			if ok != (tt.logLevel != "") || got != string(tt.logLevel) {
+				t.Errorf("expected pod template annotation %s=%q (set=%t), got %q (set=%t)", key, tt.logLevel, tt.logLevel != "", got, ok)
+			}
+		})
+	}
+}
+
+func TestDaemonSetHandlerKeepsWebhookRegistered(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	webhook := &k8sadmissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: operandAsset.NewMutatingWebhookConfiguration().Name()},
+	}
+	fakeKubeClient := kubefake.NewClientset(webhook)
+	recorder := events.NewLoggingEventRecorder("test", clock.RealClock{})
+
+	kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+	deployInterface := deploy.NewDaemonSetInstall(kubeInformerFactory.Apps().V1().DaemonSets().Lister(), createTestOperandContext(), operandAsset, fakeKubeClient, recorder)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeInformerFactory.Start(ctx.Done())
+	kubeInformerFactory.WaitForCacheSync(ctx.Done())
+
+	handler := NewDaemonSetHandler(fakeKubeClient, recorder, operandAsset, deployInterface)
+
+	rodoo := createTestRodoo(3600, nil)
+	rodoo.Status.Hash.Configuration = "changed"
+	if _, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, action := range fakeKubeClient.Actions() {
+		if action.GetResource().Resource == "mutatingwebhookconfigurations" {
+			t.Errorf("expected the rollout not to touch the MutatingWebhookConfiguration, got %s", action.GetVerb())
+		}
+	}
+	if _, err := fakeKubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, webhook.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the MutatingWebhookConfiguration to stay registered: %v", err)
+	}
+
+	daemonSet, err := fakeKubeClient.AppsV1().DaemonSets("test-namespace").Get(ctx, operandAsset.DaemonSet().Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected DaemonSet to be created: %v", err)
+	}
+	strategy := daemonSet.Spec.UpdateStrategy
+	if strategy.Type != k8sappsv1.RollingUpdateDaemonSetStrategyType || strategy.RollingUpdate == nil ||
+		strategy.RollingUpdate.MaxUnavailable.IntValue() != 1 || strategy.RollingUpdate.MaxSurge.IntValue() != 0 {
+		t.Errorf("expected a rolling update of one node at a time, got %+v", strategy)
+	}
+}
+
+func TestDaemonSetHandlerOperandPlacement(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	fakeKubeClient := kubefake.NewClientset()
+	recorder := events.NewLoggingEventRecorder("test", clock.RealClock{})
+
+	kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+	daemonSetInformer := kubeInformerFactory.Apps().V1().DaemonSets()
+	deployInterface := deploy.NewDaemonSetInstall(daemonSetInformer.Lister(), createTestOperandContext(), operandAsset, fakeKubeClient, recorder)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeInformerFactory.Start(ctx.Done())
+	kubeInformerFactory.WaitForCacheSync(ctx.Done())
+
+	handler := NewDaemonSetHandler(fakeKubeClient, recorder, operandAsset, deployInterface)
+	hashKey := operandAsset.Values().PlacementHashAnnotationKey
+
+	getDaemonSet := func() *k8sappsv1.DaemonSet {
+		daemonSet, err := fakeKubeClient.AppsV1().DaemonSets("test-namespace").Get(ctx, operandAsset.DaemonSet().Name(), metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("expected DaemonSet to exist: %v", err)
+		}
+		return daemonSet
+	}
+
+	rodoo := createTestRodoo(3600, nil)
+	current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current.Status.Hash.OperandPlacement != "" {
+		t.Errorf("expected no placement hash without a placement, got %q", current.Status.Hash.OperandPlacement)
+	}
+
+	daemonSet := getDaemonSet()
+	if _, ok := daemonSet.Spec.Template.Spec.NodeSelector["node-role.kubernetes.io/master"]; !ok {
+		t.Errorf("expected the default node selector, got %v", daemonSet.Spec.Template.Spec.NodeSelector)
+	}
+	if len(daemonSet.Spec.Template.Spec.Tolerations) != 3 {
+		t.Errorf("expected the default tolerations, got %v", daemonSet.Spec.Template.Spec.Tolerations)
+	}
+
+	// Wait for the lister to see the DaemonSet so that the next Handle sees the
+	// placement change rather than a missing workload.
+	for i := 0; ; i++ {
+		if _, err := daemonSetInformer.Lister().DaemonSets("test-namespace").Get(operandAsset.DaemonSet().Name()); err == nil {
+			break
+		}
+		if i == 50 {
+			t.Fatal("timed out waiting for the DaemonSet informer")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	updated := current.DeepCopy()
+	updated.Spec.OperandPlacement = runoncedurationoverridev1.OperandPlacement{
+		NodeSelector: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+		Tolerations: []corev1.Toleration{
+			{Key: "node-role.kubernetes.io/control-plane", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+		},
+		PriorityClassName: "system-cluster-critical",
+	}
+
+	current, _, err = handler.Handle(NewReconcileRequestContext(createTestOperandContext()), updated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current.Status.Hash.OperandPlacement == "" {
+		t.Fatalf("expected a placement hash")
+	}
+
+	daemonSet = getDaemonSet()
+	if got := daemonSet.GetAnnotations()[hashKey]; got != current.Status.Hash.OperandPlacement {
+		t.Errorf("expected annotation %s=%q, got %q", hashKey, current.Status.Hash.OperandPlacement, got)
+	}
+	spec := daemonSet.Spec.Template.Spec
+	if len(spec.NodeSelector) != 1 || spec.NodeSelector["node-role.kubernetes.io/control-plane"] != "" {
+		t.Errorf("expected the node selector of the placement, got %v", spec.NodeSelector)
+	}
+	if len(spec.Tolerations) != 1 || spec.Tolerations[0].Key != "node-role.kubernetes.io/control-plane" {
+		t.Errorf("expected the tolerations of the placement, got %v", spec.Tolerations)
+	}
+	if spec.PriorityClassName != "system-cluster-critical" {
+		t.Errorf("expected priorityClassName system-cluster-critical, got %q", spec.PriorityClassName)
+	}
+	if cpu := spec.Containers[0].Resources.Requests[corev1.ResourceCPU]; cpu.String() != "10m" {
+		t.Errorf("expected a cpu request of 10m, got %v", spec.Containers[0].Resources)
+	}
+}
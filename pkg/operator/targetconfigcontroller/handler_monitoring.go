@@ -0,0 +1,105 @@
+package targetconfigcontroller
+
+import (
+	gocontext "context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+func NewMonitoringHandler(client kubernetes.Interface, dynamicClient dynamic.Interface, recorder events.Recorder, asset *asset.Asset) *monitoringHandler {
+	return &monitoringHandler{
+		client:        client,
+		dynamicClient: dynamicClient,
+		recorder:      recorder,
+		asset:         asset,
+	}
+}
+
+// monitoringHandler ensures the Service in front of the operator metrics
+// endpoint, the ServiceMonitor that has cluster monitoring scrape it, and the
+// PrometheusRule with the alerts on the webhook server. The monitoring.coreos.com
+// resources are skipped when the monitoring API is not installed.
+type monitoringHandler struct {
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	recorder      events.Recorder
+	asset         *asset.Asset
+}
+
+func (m *monitoringHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
+	current = original
+	ctx := gocontext.TODO()
+	monitoring := m.asset.Monitoring()
+
+	service := monitoring.MetricsService()
+	context.ControllerSetter().Set(service, original)
+	if _, _, err := apply.Service(ctx, m.client.CoreV1(), m.recorder, service); err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	role := monitoring.PrometheusRole()
+	context.ControllerSetter().Set(role, original)
+	if _, _, err := apply.Role(ctx, m.client.RbacV1(), m.recorder, role); err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	binding := monitoring.PrometheusRoleBinding()
+	context.ControllerSetter().Set(binding, original)
+	if _, _, err := apply.RoleBinding(ctx, m.client.RbacV1(), m.recorder, binding); err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	serviceMonitor := monitoring.ServiceMonitor()
+	context.ControllerSetter().Set(serviceMonitor, original)
+	if _, _, err := apply.ServiceMonitor(ctx, m.dynamicClient, m.recorder, serviceMonitor); err != nil {
+		if k8serrors.IsNotFound(err) {
+			klog.V(4).Infof("key=%s monitoring API is not installed, skipping the ServiceMonitor and PrometheusRule", original.Name)
+			return
+		}
+
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	rule := m.NewPrometheusRule(original)
+	if original.Status.DeploymentMode == appsv1.DeploymentModeAdmissionPolicy {
+		// There is no webhook server to alert on.
+		if _, _, err := resourceapply.DeletePrometheusRule(ctx, m.dynamicClient, m.recorder, rule); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		}
+		return
+	}
+
+	context.ControllerSetter().Set(rule, original)
+	if _, _, err := apply.PrometheusRule(ctx, m.dynamicClient, m.recorder, rule); err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	klog.V(4).Infof("key=%s resource=PrometheusRule/%s is in sync", original.Name, rule.GetName())
+	return
+}
+
+// NewPrometheusRule returns the PrometheusRule with thresholds that follow the
+// webhook and certificate settings of the RunOnceDurationOverride spec.
+func (m *monitoringHandler) NewPrometheusRule(cro *appsv1.RunOnceDurationOverride) *unstructured.Unstructured {
+	return m.asset.PrometheusRule().
+		WithWorkload(cro.Spec.GetWorkload()).
+		WithWebhook(cro.Spec.Webhook.FailurePolicy, cro.Spec.Webhook.TimeoutSeconds).
+		WithCertRotationThreshold(cro.Spec.Certificate.GetRotationThreshold()).
+		New()
+}
@@ -0,0 +1,177 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	kubetesting "k8s.io/client-go/testing"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	applyfake "github.com/openshift/run-once-duration-override-operator/pkg/apply/fake"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+var (
+	serviceMonitorGVR = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "servicemonitors"}
+	prometheusRuleGVR = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1", Resource: "prometheusrules"}
+)
+
+func TestMonitoringHandler(t *testing.T) {
+	tests := []struct {
+		name               string
+		monitoringServed   bool
+		wantServiceMonitor bool
+	}{
+		{
+			name:               "MonitoringAPIServed",
+			monitoringServed:   true,
+			wantServiceMonitor: true,
+		},
+		{
+			name:             "MonitoringAPINotServed",
+			monitoringServed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operandAsset := asset.New(createTestOperandContext())
+			fakeKubeClient := kubefake.NewClientset()
+			fakeDynamicClient := applyfake.NewSimpleDynamicClient(runtime.NewScheme())
+			if !tt.monitoringServed {
+				fakeDynamicClient.PrependReactor("*", "servicemonitors", func(action kubetesting.Action) (bool, runtime.Object, error) {
+					return true, nil, k8serrors.NewNotFound(serviceMonitorGVR.GroupResource(), "")
+				})
+			}
+
+			handler := NewMonitoringHandler(fakeKubeClient, fakeDynamicClient, events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset)
+
+			rodoo := createTestRodoo(3600, nil)
+			if _, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ctx := context.TODO()
+			monitoring := operandAsset.Monitoring()
+			namespace := operandAsset.Values().Namespace
+
+			service, err := fakeKubeClient.CoreV1().Services(namespace).Get(ctx, monitoring.MetricsServiceName(), metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected the metrics Service to be created: %v", err)
+			}
+			if got := service.Spec.Selector[asset.OperatorSelectorLabelKey]; got != asset.OperatorSelectorLabelValue {
+				t.Errorf("expected the metrics Service to select the operator pods, got selector %v", service.Spec.Selector)
+			}
+			if got := service.Annotations[asset.ServingCertSecretAnnotationName]; got != monitoring.MetricsSecretName() {
+				t.Errorf("expected the metrics Service to request serving cert %q, got %q", monitoring.MetricsSecretName(), got)
+			}
+
+			if _, err := fakeKubeClient.RbacV1().Roles(namespace).Get(ctx, "prometheus-k8s", metav1.GetOptions{}); err != nil {
+				t.Errorf("expected the prometheus Role to be created: %v", err)
+			}
+			if _, err := fakeKubeClient.RbacV1().RoleBindings(namespace).Get(ctx, "prometheus-k8s", metav1.GetOptions{}); err != nil {
+				t.Errorf("expected the prometheus RoleBinding to be created: %v", err)
+			}
+
+			serviceMonitor, err := fakeDynamicClient.Resource(serviceMonitorGVR).Namespace(namespace).Get(ctx, monitoring.MetricsServiceName(), metav1.GetOptions{})
+			if !tt.wantServiceMonitor {
+				if !k8serrors.IsNotFound(err) {
+					t.Errorf("expected no ServiceMonitor, got err=%v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected the ServiceMonitor to be created: %v", err)
+			}
+			if refs := serviceMonitor.GetOwnerReferences(); len(refs) != 1 || refs[0].Name != rodoo.Name {
+				t.Errorf("expected the ServiceMonitor to be owned by %q, got %v", rodoo.Name, refs)
+			}
+
+			if _, err := fakeDynamicClient.Resource(prometheusRuleGVR).Namespace(namespace).Get(ctx, operandAsset.PrometheusRule().Name(), metav1.GetOptions{}); err != nil {
+				t.Errorf("expected the PrometheusRule to be created: %v", err)
+			}
+		})
+	}
+}
+
+func TestMonitoringHandlerPrometheusRule(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	fakeDynamicClient := applyfake.NewSimpleDynamicClient(runtime.NewScheme())
+	handler := NewMonitoringHandler(kubefake.NewClientset(), fakeDynamicClient, events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset)
+
+	rodoo := createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+		rodoo.Spec.Webhook.FailurePolicy = admissionregistrationv1.Ignore
+		rodoo.Spec.Webhook.TimeoutSeconds = 10
+		rodoo.Spec.Certificate.RotationThreshold = &metav1.Duration{Duration: 72 * time.Hour}
+		rodoo.Spec.Workload = runoncedurationoverridev1.WorkloadDeployment
+	})
+	if _, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.TODO()
+	rules := fakeDynamicClient.Resource(prometheusRuleGVR).Namespace(operandAsset.Values().Namespace)
+	rule, err := rules.Get(ctx, operandAsset.PrometheusRule().Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the PrometheusRule to be created: %v", err)
+	}
+
+	groups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	if len(groups) != 1 {
+		t.Fatalf("expected one rule group, got %d", len(groups))
+	}
+	alerts := map[string]map[string]interface{}{}
+	items, _, _ := unstructured.NestedSlice(groups[0].(map[string]interface{}), "rules")
+	for _, item := range items {
+		alert := item.(map[string]interface{})
+		alerts[alert["alert"].(string)] = alert
+
+		if url, _, _ := unstructured.NestedString(alert, "annotations", "runbook_url"); !strings.HasPrefix(url, asset.RunbookBaseURL) {
+			t.Errorf("expected alert %s to link to a runbook, got %q", alert["alert"], url)
+		}
+	}
+
+	expectations := []struct {
+		alert    string
+		expr     string
+		severity string
+	}{
+		{alert: "RunOnceDurationOverrideWebhookPodsUnavailable", expr: "kube_deployment_status_replicas_unavailable", severity: "warning"},
+		{alert: "RunOnceDurationOverrideWebhookErrors", expr: operandAsset.NewMutatingWebhookConfiguration().Name(), severity: "warning"},
+		{alert: "RunOnceDurationOverrideWebhookLatencyHigh", expr: "> 5", severity: "warning"},
+		{alert: "RunOnceDurationOverrideServingCertExpiring", expr: "< 259200", severity: "warning"},
+	}
+	for _, e := range expectations {
+		alert, ok := alerts[e.alert]
+		if !ok {
+			t.Errorf("expected alert %s", e.alert)
+			continue
+		}
+		if expr := alert["expr"].(string); !strings.Contains(expr, e.expr) {
+			t.Errorf("expected the expression of %s to contain %q, got %q", e.alert, e.expr, expr)
+		}
+		if severity, _, _ := unstructured.NestedString(alert, "labels", "severity"); severity != e.severity {
+			t.Errorf("expected %s to have severity %q, got %q", e.alert, e.severity, severity)
+		}
+	}
+
+	// The AdmissionPolicy deployment mode has no webhook server to alert on.
+	rodoo.Status.DeploymentMode = runoncedurationoverridev1.DeploymentModeAdmissionPolicy
+	if _, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := rules.Get(ctx, operandAsset.PrometheusRule().Name(), metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the PrometheusRule to be removed, got err=%v", err)
+	}
+}
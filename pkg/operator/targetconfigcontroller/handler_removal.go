@@ -0,0 +1,268 @@
+package targetconfigcontroller
+
+import (
+	gocontext "context"
+	"fmt"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+func NewRemovalHandler(client kubernetes.Interface, recorder events.Recorder, asset *asset.Asset) *removalHandler {
+	return &removalHandler{
+		client:   client,
+		recorder: recorder,
+		asset:    asset,
+	}
+}
+
+// WithDynamicClient sets the client the resources of the AdmissionPolicy
+// deployment mode are removed through the v1 API with.
+func (r *removalHandler) WithDynamicClient(client dynamic.Interface) *removalHandler {
+	r.dynamicClient = client
+	return r
+}
+
+// removalHandler tears down all operand resources when the operator is
+// in the Removed management state. Its Remove* methods are also used to
+// clean up after a deployment mode switch.
+type removalHandler struct {
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	recorder      events.Recorder
+	asset         *asset.Asset
+}
+
+func (r *removalHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
+	current = original
+
+	if original.Status.DeploymentMode == appsv1.DeploymentModeAdmissionPolicy {
+		if err := r.RemoveAdmissionPolicy(); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.CannotRemoveOperand, err)
+			return
+		}
+	}
+
+	if err := r.RemoveWebhook(); err != nil {
+		handleErr = NewInstallReadinessError(appsv1.CannotRemoveOperand, err)
+		return
+	}
+
+	// Nothing that the status points to exists anymore.
+	current.Status.Resources = appsv1.RunOnceDurationOverrideResources{}
+	current.Status.Hash = appsv1.RunOnceDurationOverrideResourceHash{}
+	current.Status.CertsRotateAt = metav1.Time{}
+	current.Status.Generations = nil
+	current.Status.DeploymentMode = ""
+	current.Status.Workload = ""
+	current.Status.CertSource = ""
+	current.Status.CertRotation = appsv1.CertRotationStatus{}
+	current.Status.Certificates = appsv1.CertificatesStatus{}
+
+	v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+		Type:   appsv1.InstallReadinessFailure,
+		Status: operatorv1.ConditionFalse,
+	})
+	v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+		Type:    "Available",
+		Status:  operatorv1.ConditionFalse,
+		Reason:  appsv1.OperandRemoved,
+		Message: "managementState is Removed, operand resources have been removed",
+	})
+	v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+		Type:   operatorv1.OperatorStatusTypeProgressing,
+		Status: operatorv1.ConditionFalse,
+		Reason: appsv1.OperandRemoved,
+	})
+
+	klog.V(2).Infof("key=%s operand resources removed", original.Name)
+	return
+}
+
+// RemoveWebhook removes the resources of the Webhook deployment mode.
+func (r *removalHandler) RemoveWebhook() error {
+	ctx := gocontext.TODO()
+
+	// The webhook configuration goes first so that pod admission does not fail
+	// while the webhook server is being torn down.
+	if err := r.RemoveWebhookConfiguration(); err != nil {
+		return err
+	}
+
+	for _, workload := range []appsv1.WorkloadType{appsv1.WorkloadDaemonSet, appsv1.WorkloadDeployment} {
+		if err := r.RemoveWorkload(workload); err != nil {
+			return err
+		}
+	}
+
+	if err := r.RemoveRBAC(); err != nil {
+		return err
+	}
+
+	if _, _, err := resourceapply.DeleteConfigMap(ctx, r.client.CoreV1(), r.recorder, r.asset.Configuration().New()); err != nil {
+		return err
+	}
+
+	return r.RemoveServingCert()
+}
+
+// RemoveWebhookConfiguration removes the MutatingWebhookConfiguration.
+func (r *removalHandler) RemoveWebhookConfiguration() error {
+	name := r.asset.NewMutatingWebhookConfiguration().Name()
+	if err := r.client.AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(gocontext.TODO(), name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete MutatingWebhookConfiguration - %s", err.Error())
+	}
+
+	return nil
+}
+
+// RemoveServingCert removes the serving cert Secrets and the CA bundle ConfigMap.
+func (r *removalHandler) RemoveServingCert() error {
+	ctx := gocontext.TODO()
+
+	if _, _, err := resourceapply.DeleteConfigMap(ctx, r.client.CoreV1(), r.recorder, r.asset.CABundleConfigMap().New()); err != nil {
+		return err
+	}
+
+	for _, secret := range []*corev1.Secret{r.asset.ServiceServingSecret().New(), r.asset.ServiceServingSecret().NewStaged()} {
+		if _, _, err := resourceapply.DeleteSecret(ctx, r.client.CoreV1(), r.recorder, secret); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveWorkload removes the resources that run the webhook server as the given workload.
+func (r *removalHandler) RemoveWorkload(workload appsv1.WorkloadType) error {
+	ctx := gocontext.TODO()
+
+	if workload == appsv1.WorkloadDeployment {
+		if _, _, err := resourceapply.DeleteDeployment(ctx, r.client.AppsV1(), r.recorder, r.asset.Deployment().New()); err != nil {
+			return err
+		}
+
+		_, _, err := resourceapply.DeleteService(ctx, r.client.CoreV1(), r.recorder, r.asset.Service().New())
+		return err
+	}
+
+	_, _, err := resourceapply.DeleteDaemonSet(ctx, r.client.AppsV1(), r.recorder, r.asset.DaemonSet().New())
+	return err
+}
+
+// RemoveAdmissionPolicy removes the resources of the AdmissionPolicy deployment
+// mode. They are deleted through each version of the API, a version that is
+// not served returns NotFound.
+func (r *removalHandler) RemoveAdmissionPolicy() error {
+	ctx := gocontext.TODO()
+
+	name := r.asset.MutatingAdmissionPolicyBinding().Name()
+	if err := r.client.AdmissionregistrationV1beta1().MutatingAdmissionPolicyBindings().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete MutatingAdmissionPolicyBinding - %s", err.Error())
+	}
+	if r.dynamicClient != nil {
+		if err := r.dynamicClient.Resource(apply.MutatingAdmissionPolicyBindingV1GVR).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete MutatingAdmissionPolicyBinding - %s", err.Error())
+		}
+	}
+
+	name = r.asset.MutatingAdmissionPolicy().Name()
+	if err := r.client.AdmissionregistrationV1beta1().MutatingAdmissionPolicies().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete MutatingAdmissionPolicy - %s", err.Error())
+	}
+	if r.dynamicClient != nil {
+		if err := r.dynamicClient.Resource(apply.MutatingAdmissionPolicyV1GVR).Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete MutatingAdmissionPolicy - %s", err.Error())
+		}
+	}
+
+	return r.RemoveAuditPolicy()
+}
+
+// RemoveAuditPolicy removes the ValidatingAdmissionPolicy and its binding that
+// the Audit mode of the AdmissionPolicy deployment mode warns with.
+func (r *removalHandler) RemoveAuditPolicy() error {
+	ctx := gocontext.TODO()
+
+	name := r.asset.AuditValidatingAdmissionPolicyBinding().Name()
+	if err := r.client.AdmissionregistrationV1().ValidatingAdmissionPolicyBindings().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ValidatingAdmissionPolicyBinding - %s", err.Error())
+	}
+
+	name = r.asset.AuditValidatingAdmissionPolicy().Name()
+	if err := r.client.AdmissionregistrationV1().ValidatingAdmissionPolicies().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ValidatingAdmissionPolicy - %s", err.Error())
+	}
+
+	return nil
+}
+
+func (r *removalHandler) RemoveRBAC() error {
+	for _, item := range r.asset.RBAC().New() {
+		if err := r.removeRBACItem(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveClusterRBAC removes the RBAC resources outside of the operand
+// namespace, which are not garbage collected through owner references: the
+// ClusterRoleBindings, then the ClusterRoles, then the RoleBinding in kube-system.
+func (r *removalHandler) RemoveClusterRBAC() error {
+	list := r.asset.RBAC().New()
+	for _, resource := range []string{"clusterrolebindings", "clusterroles", "rolebindings"} {
+		for _, item := range list {
+			if item.Resource != resource || item.Object.GetNamespace() == r.asset.Values().Namespace {
+				continue
+			}
+
+			if err := r.removeRBACItem(item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *removalHandler) removeRBACItem(item *asset.RBACItem) error {
+	ctx := gocontext.TODO()
+
+	var err error
+	switch obj := item.Object.(type) {
+	case *corev1.ServiceAccount:
+		_, _, err = resourceapply.DeleteServiceAccount(ctx, r.client.CoreV1(), r.recorder, obj)
+	case *rbacv1.Role:
+		_, _, err = resourceapply.DeleteRole(ctx, r.client.RbacV1(), r.recorder, obj)
+	case *rbacv1.RoleBinding:
+		_, _, err = resourceapply.DeleteRoleBinding(ctx, r.client.RbacV1(), r.recorder, obj)
+	case *rbacv1.ClusterRole:
+		_, _, err = resourceapply.DeleteClusterRole(ctx, r.client.RbacV1(), r.recorder, obj)
+	case *rbacv1.ClusterRoleBinding:
+		_, _, err = resourceapply.DeleteClusterRoleBinding(ctx, r.client.RbacV1(), r.recorder, obj)
+	default:
+		return fmt.Errorf("unsupported RBAC resource type: %T", item.Object)
+	}
+
+	if err != nil {
+		return fmt.Errorf("resource=%s failed to remove RBAC - %s", item.Resource, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+func TestRemovalHandler(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+
+	objects := []runtime.Object{
+		operandAsset.DaemonSet().New(),
+		operandAsset.NewMutatingWebhookConfiguration().New(),
+		operandAsset.Configuration().New(),
+		operandAsset.CABundleConfigMap().New(),
+		operandAsset.ServiceServingSecret().New(),
+	}
+	for _, item := range operandAsset.RBAC().New() {
+		objects = append(objects, item.Object)
+	}
+	fakeKubeClient := kubefake.NewClientset(objects...)
+
+	handler := NewRemovalHandler(fakeKubeClient, events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset)
+
+	rodoo := createTestRodoo(3600, withDaemonSetHandlerStatus)
+	rodoo.Spec.ManagementState = operatorv1.Removed
+
+	current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.TODO()
+	name := operandAsset.NewMutatingWebhookConfiguration().Name()
+	if _, err := fakeKubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, name, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected MutatingWebhookConfiguration %q to be removed, got err=%v", name, err)
+	}
+
+	ds := operandAsset.DaemonSet().New()
+	if _, err := fakeKubeClient.AppsV1().DaemonSets(ds.Namespace).Get(ctx, ds.Name, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected DaemonSet %q to be removed, got err=%v", ds.Name, err)
+	}
+
+	for _, cm := range []*corev1.ConfigMap{operandAsset.Configuration().New(), operandAsset.CABundleConfigMap().New()} {
+		if _, err := fakeKubeClient.CoreV1().ConfigMaps(cm.Namespace).Get(ctx, cm.Name, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+			t.Errorf("expected ConfigMap %q to be removed, got err=%v", cm.Name, err)
+		}
+	}
+
+	secret := operandAsset.ServiceServingSecret().New()
+	if _, err := fakeKubeClient.CoreV1().Secrets(secret.Namespace).Get(ctx, secret.Name, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected Secret %q to be removed, got err=%v", secret.Name, err)
+	}
+
+	clusterRoleBindings, err := fakeKubeClient.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusterRoleBindings.Items) != 0 {
+		t.Errorf("expected all ClusterRoleBindings to be removed, got %d", len(clusterRoleBindings.Items))
+	}
+
+	if current.Status.Resources.ConfigurationRef != nil || current.Status.Hash.ServingCert != "" {
+		t.Errorf("expected status resources and hashes to be cleared, got %+v %+v", current.Status.Resources, current.Status.Hash)
+	}
+	verifyCondition(t, current, "Available", operatorv1.ConditionFalse, runoncedurationoverridev1.OperandRemoved)
+
+	// A second pass over already removed resources must succeed.
+	if _, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), current); err != nil {
+		t.Fatalf("unexpected error on second pass: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package targetconfigcontroller
+
+import (
+	gocontext "context"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apis/reference"
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+func NewServiceHandler(client kubernetes.Interface, recorder events.Recorder, asset *asset.Asset) *serviceHandler {
+	return &serviceHandler{
+		client:   client,
+		recorder: recorder,
+		asset:    asset,
+	}
+}
+
+// serviceHandler ensures the Service that exposes the Deployment workload to
+// the API server. With the ServiceCA cert source the Service also requests the
+// serving cert from the service-ca operator.
+type serviceHandler struct {
+	client   kubernetes.Interface
+	recorder events.Recorder
+	asset    *asset.Asset
+}
+
+func (s *serviceHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
+	current = original
+
+	desired := s.asset.Service().New()
+	if original.Spec.GetCertSource() != appsv1.CertSourceServiceCA {
+		// The serving cert is generated by the operator, ask the service-ca
+		// operator to stop issuing one in case it did before. The annotation
+		// is removed as the operator no longer applies it.
+		delete(desired.Annotations, asset.ServingCertSecretAnnotationName)
+	}
+	context.ControllerSetter().Set(desired, original)
+
+	object, _, err := apply.Service(gocontext.TODO(), s.client.CoreV1(), s.recorder, desired)
+	if err != nil {
+		handleErr = NewInstallReadinessError(appsv1.InternalError, err)
+		return
+	}
+
+	if ref := current.Status.Resources.ServiceRef; ref != nil && ref.ResourceVersion == object.ResourceVersion {
+		klog.V(2).Infof("key=%s resource=%T/%s is in sync", original.Name, object, object.Name)
+		return
+	}
+
+	newRef, err := reference.GetReference(object)
+	if err != nil {
+		handleErr = NewInstallReadinessError(appsv1.CannotSetReference, err)
+		return
+	}
+
+	klog.V(2).Infof("key=%s resource=%T/%s resource-version=%s setting object reference", original.Name, object, object.Name, newRef.ResourceVersion)
+	current.Status.Resources.ServiceRef = newRef
+	return
+}
@@ -10,9 +10,9 @@ import (
 	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openshift/library-go/pkg/operator/events"
-	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
 	"github.com/openshift/run-once-duration-override-operator/pkg/apis/reference"
 	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
 	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
 )
 
@@ -69,7 +69,7 @@ func (c *serviceCAConfigMapHandler) Handle(context *ReconcileRequestContext, ori
 		// ask service-ca operator to provide with the serving cert.
 		desired.Annotations[ServiceCAInjectBundle] = "true"
 
-		cm, _, err := resourceapply.ApplyConfigMap(gocontext.TODO(), c.client.CoreV1(), c.recorder, desired)
+		cm, _, err := apply.ConfigMap(gocontext.TODO(), c.client.CoreV1(), c.recorder, desired)
 		if err != nil {
 			handleErr = NewInstallReadinessError(appsv1.CertNotAvailable, err)
 			return
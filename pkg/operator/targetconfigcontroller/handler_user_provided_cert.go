@@ -0,0 +1,98 @@
+package targetconfigcontroller
+
+import (
+	operatorv1 "github.com/openshift/api/operator/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apis/reference"
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+)
+
+func NewUserProvidedCertHandler(secretLister listerscorev1.SecretLister, configMapLister listerscorev1.ConfigMapLister, asset *asset.Asset) *userProvidedCertHandler {
+	return &userProvidedCertHandler{
+		secretLister:    secretLister,
+		configMapLister: configMapLister,
+		asset:           asset,
+	}
+}
+
+// userProvidedCertHandler points the serving cert references at the Secret and
+// ConfigMap of spec.userProvidedCert, in place of certGenerationHandler. The
+// certs are validated by certReadyHandler. The Secret and ConfigMap are owned
+// by the user and never written to, isOwnedByOperatorOrUserProvidedCert enqueues the object on
+// their events.
+type userProvidedCertHandler struct {
+	secretLister    listerscorev1.SecretLister
+	configMapLister listerscorev1.ConfigMapLister
+	asset           *asset.Asset
+}
+
+func (u *userProvidedCertHandler) Handle(ctx *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
+	current = original
+	config := original.Spec.UserProvidedCert
+
+	secret, err := u.secretLister.Secrets(ctx.WebhookNamespace()).Get(config.SecretName)
+	if err != nil {
+		handleErr = userProvidedCertUnusable(current, err)
+		return
+	}
+
+	configmap, err := u.configMapLister.ConfigMaps(ctx.WebhookNamespace()).Get(config.CAConfigMapName)
+	if err != nil {
+		handleErr = userProvidedCertUnusable(current, err)
+		return
+	}
+
+	if current.Status.Resources.ServiceCertSecretRef, err = reference.GetReference(secret); err != nil {
+		handleErr = NewInstallReadinessError(appsv1.CannotSetReference, err)
+		return
+	}
+	if current.Status.Resources.ServiceCAConfigMapRef, err = reference.GetReference(configmap); err != nil {
+		handleErr = NewInstallReadinessError(appsv1.CannotSetReference, err)
+		return
+	}
+
+	// The self-signed certs are not rotated by the operator in this mode.
+	current.Status.CertsRotateAt = metav1.Time{}
+	current.Status.CertRotation.Phase = ""
+
+	klog.V(2).Infof("key=%s resource=%T/%s using user provided serving cert", original.Name, secret, secret.Name)
+	return
+}
+
+// userProvidedCertUnusable reports the user provided cert as unusable with a
+// Degraded condition, and returns the error that stops the handler chain.
+func userProvidedCertUnusable(current *appsv1.RunOnceDurationOverride, err error) error {
+	v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+		Type:    operatorv1.OperatorStatusTypeDegraded,
+		Status:  operatorv1.ConditionTrue,
+		Reason:  appsv1.UserProvidedCertInvalid,
+		Message: err.Error(),
+	})
+
+	return NewInstallReadinessError(appsv1.UserProvidedCertInvalid, err)
+}
+
+// caBundleKey returns the ConfigMap key of the CA bundle for the cert source of the given object.
+func caBundleKey(cro *appsv1.RunOnceDurationOverride) string {
+	if cro.Spec.GetCertSource() == appsv1.CertSourceUserProvided {
+		return appsv1.UserProvidedCABundleKey
+	}
+
+	return "service-ca.crt"
+}
+
+// servingCertSecretName returns the name of the Secret the webhook server pods
+// mount their serving cert from.
+func servingCertSecretName(asset *asset.Asset, cro *appsv1.RunOnceDurationOverride) string {
+	if cro.Spec.GetCertSource() == appsv1.CertSourceUserProvided && cro.Spec.UserProvidedCert != nil {
+		return cro.Spec.UserProvidedCert.SecretName
+	}
+
+	return asset.ServiceServingSecret().Name()
+}
@@ -0,0 +1,179 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+	"github.com/openshift/run-once-duration-override-operator/pkg/cert"
+	runoncedurationoverridev1listers "github.com/openshift/run-once-duration-override-operator/pkg/generated/listers/runoncedurationoverride/v1"
+)
+
+func withUserProvidedCert(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+	rodoo.Spec.CertSource = runoncedurationoverridev1.CertSourceUserProvided
+	rodoo.Spec.UserProvidedCert = &runoncedurationoverridev1.UserProvidedCertConfig{
+		SecretName:      "pki-serving-cert",
+		CAConfigMapName: "pki-ca",
+	}
+}
+
+func newUserProvidedCertObjects(t *testing.T, hosts []string) []runtime.Object {
+	bundle, err := cert.GenerateWithServing(time.Now().Add(90*24*time.Hour), "pki", hosts, runoncedurationoverridev1.KeyAlgorithmECDSAP256)
+	if err != nil {
+		t.Fatalf("failed to generate cert: %v", err)
+	}
+
+	return []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "pki-serving-cert", Namespace: "test-namespace"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": bundle.ServiceCert, "tls.key": bundle.ServiceKey},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "pki-ca", Namespace: "test-namespace"},
+			Data:       map[string]string{runoncedurationoverridev1.UserProvidedCABundleKey: string(bundle.ServingCertCA)},
+		},
+	}
+}
+
+func TestUserProvidedCert(t *testing.T) {
+	tests := []struct {
+		name        string
+		objects     func(t *testing.T) []runtime.Object
+		expectValid bool
+	}{
+		{
+			name:        "Valid",
+			objects:     func(t *testing.T) []runtime.Object { return newUserProvidedCertObjects(t, []string{"localhost"}) },
+			expectValid: true,
+		},
+		{
+			name: "MissingLocalhostSAN",
+			objects: func(t *testing.T) []runtime.Object {
+				return newUserProvidedCertObjects(t, []string{"webhook.example.com"})
+			},
+			expectValid: false,
+		},
+		{
+			name:        "SecretNotFound",
+			objects:     func(t *testing.T) []runtime.Object { return newUserProvidedCertObjects(t, []string{"localhost"})[1:] },
+			expectValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operandAsset := asset.New(createTestOperandContext())
+			fakeKubeClient := kubefake.NewClientset(tt.objects(t)...)
+			kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+			secretLister := kubeInformerFactory.Core().V1().Secrets().Lister()
+			configMapLister := kubeInformerFactory.Core().V1().ConfigMaps().Lister()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			kubeInformerFactory.Start(ctx.Done())
+			kubeInformerFactory.WaitForCacheSync(ctx.Done())
+
+			rodoo := createTestRodoo(3600, withUserProvidedCert)
+			reconcileContext := NewReconcileRequestContext(createTestOperandContext())
+
+			var err error
+			current := rodoo
+			for _, handler := range []Handler{
+				NewUserProvidedCertHandler(secretLister, configMapLister, operandAsset),
+				NewCertReadyHandler(fakeKubeClient, secretLister, configMapLister, operandAsset),
+			} {
+				if current, _, err = handler.Handle(reconcileContext, current); err != nil {
+					break
+				}
+			}
+
+			if !tt.expectValid {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if reason := GetReason(err); reason != runoncedurationoverridev1.UserProvidedCertInvalid {
+					t.Errorf("expected reason %s, got %s", runoncedurationoverridev1.UserProvidedCertInvalid, reason)
+				}
+				verifyCondition(t, current, operatorv1.OperatorStatusTypeDegraded, operatorv1.ConditionTrue, runoncedurationoverridev1.UserProvidedCertInvalid)
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			verifyCondition(t, current, operatorv1.OperatorStatusTypeDegraded, operatorv1.ConditionFalse, runoncedurationoverridev1.AsExpected)
+			if current.Status.Resources.ServiceCertSecretRef == nil || current.Status.Resources.ServiceCertSecretRef.Name != "pki-serving-cert" {
+				t.Errorf("expected ServiceCertSecretRef to point at the user provided Secret, got %+v", current.Status.Resources.ServiceCertSecretRef)
+			}
+			if current.Status.Certificates.Serving.NotAfter.Before(&metav1.Time{Time: time.Now().Add(89 * 24 * time.Hour)}) {
+				t.Errorf("expected the expiry of the user provided cert, got %s", current.Status.Certificates.Serving.NotAfter)
+			}
+
+			secret, err := fakeKubeClient.CoreV1().Secrets("test-namespace").Get(ctx, "pki-serving-cert", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, ok := secret.Annotations[operandAsset.Values().OwnerAnnotationKey]; ok {
+				t.Errorf("expected the user provided Secret to be left alone")
+			}
+		})
+	}
+}
+
+func TestIsOwnedByOperatorOrUserProvidedCert(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(createTestRodoo(3600, withUserProvidedCert)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	filter := isOwnedByOperatorOrUserProvidedCert(runoncedurationoverridev1listers.NewRunOnceDurationOverrideLister(indexer), "test-namespace")
+
+	tests := []struct {
+		name   string
+		obj    interface{}
+		expect bool
+	}{
+		{
+			name:   "Secret",
+			obj:    &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "pki-serving-cert", Namespace: "test-namespace"}},
+			expect: true,
+		},
+		{
+			name:   "ConfigMap",
+			obj:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "pki-ca", Namespace: "test-namespace"}},
+			expect: true,
+		},
+		{
+			name:   "DeletedSecret",
+			obj:    cache.DeletedFinalStateUnknown{Obj: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "pki-serving-cert", Namespace: "test-namespace"}}},
+			expect: true,
+		},
+		{
+			name:   "ConfigMapWithSecretName",
+			obj:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "pki-serving-cert", Namespace: "test-namespace"}},
+			expect: false,
+		},
+		{
+			name:   "OtherNamespace",
+			obj:    &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "pki-serving-cert", Namespace: "other"}},
+			expect: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filter(tt.obj); got != tt.expect {
+				t.Errorf("expected %t, got %t", tt.expect, got)
+			}
+		})
+	}
+}
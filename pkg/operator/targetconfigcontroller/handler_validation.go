@@ -1,24 +1,42 @@
 package targetconfigcontroller
 
 import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
 	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
-	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-func NewValidationHandler() *validationHandler {
-	return &validationHandler{}
+func NewSpecValidator() *specValidator {
+	return &specValidator{}
 }
 
-type validationHandler struct {
+// specValidator validates the spec once for all the sub-controllers, each of
+// them reports the result under its own condition prefix. The result is kept
+// until the spec changes, so that the InvalidSpec event is only recorded once
+// for each invalid spec.
+type specValidator struct {
+	lock sync.Mutex
+	spec *appsv1.RunOnceDurationOverrideSpec
+	err  error
 }
 
-func (c *validationHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
-	current = original
+func (v *specValidator) Validate(context *ReconcileRequestContext, cro *appsv1.RunOnceDurationOverride) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.spec != nil && equality.Semantic.DeepEqual(*v.spec, cro.Spec) {
+		return v.err
+	}
 
-	validationErr := original.Spec.RunOnceDurationOverrideConfig.Spec.Validate()
-	if validationErr != nil {
-		handleErr = NewInstallReadinessError(appsv1.InvalidParameters, validationErr)
+	v.spec = cro.Spec.DeepCopy()
+	v.err = nil
+	if validationErr := cro.Spec.Validate(); validationErr != nil {
+		context.Recorder().Eventf(cro, corev1.EventTypeWarning, "InvalidSpec", "The spec is invalid: %v", validationErr)
+		v.err = NewInstallReadinessError(appsv1.InvalidParameters, validationErr)
 	}
 
-	return
+	return v.err
 }
@@ -0,0 +1,30 @@
+package targetconfigcontroller
+
+import (
+	"testing"
+
+	"k8s.io/client-go/tools/record"
+
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+)
+
+func TestSpecValidator(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	reconcileContext := NewReconcileRequestContext(createTestOperandContext()).WithRecorder(recorder)
+	validator := NewSpecValidator()
+
+	invalid := createTestRodoo(-1, nil)
+	for i := 0; i < 3; i++ {
+		// Every sub-controller validates the same spec.
+		if err := validator.Validate(reconcileContext, invalid); GetReason(err) != runoncedurationoverridev1.InvalidParameters {
+			t.Fatalf("expected reason %s, got %v", runoncedurationoverridev1.InvalidParameters, err)
+		}
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("expected a single InvalidSpec event, got %d", len(recorder.Events))
+	}
+
+	if err := validator.Validate(reconcileContext, createTestRodoo(3600, nil)); err != nil {
+		t.Errorf("expected a valid spec, got %v", err)
+	}
+}
@@ -1,19 +1,32 @@
 package targetconfigcontroller
 
 import (
+	"bytes"
 	gocontext "context"
+	"errors"
+	"fmt"
+	"strings"
 
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	k8sadmissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	admissionregistrationv1 "k8s.io/client-go/listers/admissionregistration/v1"
 	"k8s.io/klog/v2"
 	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openshift/library-go/pkg/operator/events"
-	"github.com/openshift/library-go/pkg/operator/resource/resourceapply"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
 	"github.com/openshift/run-once-duration-override-operator/pkg/apis/reference"
 	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/apply"
 	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+	"github.com/openshift/run-once-duration-override-operator/pkg/deploy"
 )
 
 func NewWebhookConfigurationHandlerHandler(client kubernetes.Interface, recorder events.Recorder, webhookLister admissionregistrationv1.MutatingWebhookConfigurationLister, asset *asset.Asset) *webhookConfigurationHandler {
@@ -22,7 +35,6 @@ func NewWebhookConfigurationHandlerHandler(client kubernetes.Interface, recorder
 		recorder:      recorder,
 		webhookLister: webhookLister,
 		asset:         asset,
-		cache:         resourceapply.NewResourceCache(),
 	}
 }
 
@@ -31,7 +43,15 @@ type webhookConfigurationHandler struct {
 	recorder      events.Recorder
 	webhookLister admissionregistrationv1.MutatingWebhookConfigurationLister
 	asset         *asset.Asset
-	cache         resourceapply.ResourceCache
+	deploy        deploy.Interface
+}
+
+// WithWorkload makes the handler wait for the given workload to be available
+// before the webhook is registered, so that pod admission is never sent to a
+// webhook server that is not running yet.
+func (w *webhookConfigurationHandler) WithWorkload(deploy deploy.Interface) *webhookConfigurationHandler {
+	w.deploy = deploy
+	return w
 }
 
 func (w *webhookConfigurationHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
@@ -40,32 +60,71 @@ func (w *webhookConfigurationHandler) Handle(context *ReconcileRequestContext, o
 
 	name := w.asset.NewMutatingWebhookConfiguration().Name()
 	object, err := w.webhookLister.Get(name)
+
+	if original.Spec.RunOnceDurationOverrideConfig.Spec.GetMode() == appsv1.OverrideModeDisabled {
+		handleErr = w.Remove(original, name, err)
+		current.Status.Resources.MutatingWebhookConfigurationRef = nil
+		return
+	}
+
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			handleErr = NewInstallReadinessError(appsv1.CertNotAvailable, err)
 			return
 		}
 
+		if w.deploy != nil {
+			if available, _ := w.deploy.IsAvailable(); !available {
+				handleErr = NewInstallReadinessError(appsv1.DeploymentNotReady, fmt.Errorf("name=%s waiting for deployment to complete before registering the webhook", w.deploy.Name()))
+				return
+			}
+		}
+
 		ensure = true
 	}
 
-	if ensure {
-		desired := w.asset.NewMutatingWebhookConfiguration().New()
-		context.ControllerSetter().Set(desired, original)
-
-		servingCertCA := context.GetBundle().ServingCertCA
-		for i := range desired.Webhooks {
-			desired.Webhooks[i].ClientConfig.CABundle = servingCertCA
+	desired := w.NewDesired(context, original)
+	if context.GetBundle() == nil {
+		// The serving cert is not usable, the cert management controller reports
+		// why. Drift is still repaired, with the CA bundle last applied.
+		if object == nil {
+			handleErr = NewInstallReadinessError(appsv1.AdmissionWebhookNotAvailable, errors.New("waiting for the serving cert to register the webhook"))
+			return
 		}
+		keepCABundle(desired, object)
+	}
+
+	drift := []string{}
+	if !ensure {
+		drift = webhookDrift(object, desired)
+	}
+
+	if len(drift) > 0 {
+		klog.V(2).Infof("key=%s resource=%T/%s drift detected in %s", original.Name, object, object.Name, strings.Join(drift, ","))
+		w.recorder.Warningf("MutatingWebhookConfigurationDrift", "MutatingWebhookConfiguration %s does not match the desired state (%s), re-applying", object.Name, strings.Join(drift, ", "))
+		ensure = true
+	}
+	setDriftCondition(current, drift)
 
-		webhook, _, err := resourceapply.ApplyMutatingWebhookConfigurationImproved(gocontext.TODO(), w.client.AdmissionregistrationV1(), w.recorder, desired, w.cache)
+	if ensure {
+		webhook, _, err := apply.MutatingWebhookConfiguration(gocontext.TODO(), w.client.AdmissionregistrationV1(), w.recorder, desired)
 		if err != nil {
 			handleErr = NewInstallReadinessError(appsv1.CertNotAvailable, err)
 			return
 		}
 
+		if object == nil {
+			// The webhook was not registered, tell a first registration from a
+			// re-creation after the MutatingWebhookConfiguration was removed.
+			if original.Status.Resources.MutatingWebhookConfigurationRef != nil {
+				context.Recorder().Eventf(original, corev1.EventTypeNormal, "MutatingWebhookConfigurationRecreated", "Re-created MutatingWebhookConfiguration %s", webhook.Name)
+			} else {
+				context.Recorder().Eventf(original, corev1.EventTypeNormal, "MutatingWebhookConfigurationCreated", "Created MutatingWebhookConfiguration %s", webhook.Name)
+			}
+		}
+
 		object = webhook
-		klog.V(2).Infof("key=%s resource=%T/%s successfully created", original.Name, object, object.Name)
+		klog.V(2).Infof("key=%s resource=%T/%s successfully ensured", original.Name, object, object.Name)
 	}
 
 	if ref := original.Status.Resources.MutatingWebhookConfigurationRef; ref != nil && ref.ResourceVersion == object.ResourceVersion {
@@ -84,3 +143,156 @@ func (w *webhookConfigurationHandler) Handle(context *ReconcileRequestContext, o
 	current.Status.Resources.MutatingWebhookConfigurationRef = newRef
 	return
 }
+
+// Remove deletes the MutatingWebhookConfiguration so that the admission webhook
+// no longer intercepts pod creation. The webhook server itself keeps running.
+func (w *webhookConfigurationHandler) Remove(original *appsv1.RunOnceDurationOverride, name string, getErr error) error {
+	switch {
+	case k8serrors.IsNotFound(getErr):
+		return nil
+	case getErr != nil:
+		return NewInstallReadinessError(appsv1.CertNotAvailable, getErr)
+	}
+
+	if err := w.client.AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(gocontext.TODO(), name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return NewInstallReadinessError(appsv1.InternalError, err)
+	}
+
+	klog.V(2).Infof("key=%s resource=MutatingWebhookConfiguration/%s removed, mode is %s", original.Name, name, appsv1.OverrideModeDisabled)
+	return nil
+}
+
+// NewDesired returns the MutatingWebhookConfiguration with the webhook settings
+// and selectors of the RunOnceDurationOverride spec and the serving CA bundle of
+// the context, if any, applied.
+func (w *webhookConfigurationHandler) NewDesired(context *ReconcileRequestContext, cro *appsv1.RunOnceDurationOverride) *k8sadmissionregistrationv1.MutatingWebhookConfiguration {
+	builder := w.asset.NewMutatingWebhookConfiguration().WithSelectors(cro.Spec.Webhook.NamespaceSelector, cro.Spec.Webhook.ObjectSelector)
+	if cro.Spec.GetWorkload() == appsv1.WorkloadDeployment {
+		builder = builder.WithServiceReference()
+	}
+
+	desired := builder.New()
+	context.ControllerSetter().Set(desired, cro)
+
+	var servingCertCA []byte
+	if bundle := context.GetBundle(); bundle != nil {
+		servingCertCA = bundle.ServingCertCA
+	}
+	for i := range desired.Webhooks {
+		desired.Webhooks[i].ClientConfig.CABundle = servingCertCA
+
+		if policy := cro.Spec.Webhook.FailurePolicy; policy != "" {
+			desired.Webhooks[i].FailurePolicy = &policy
+		}
+		if timeoutSeconds := cro.Spec.Webhook.TimeoutSeconds; timeoutSeconds != 0 {
+			desired.Webhooks[i].TimeoutSeconds = &timeoutSeconds
+		}
+	}
+
+	return desired
+}
+
+// keepCABundle sets the CA bundle of the webhooks of desired to the one of the
+// webhooks of the same name in current.
+func keepCABundle(desired, current *k8sadmissionregistrationv1.MutatingWebhookConfiguration) {
+	for i := range desired.Webhooks {
+		for _, webhook := range current.Webhooks {
+			if webhook.Name == desired.Webhooks[i].Name {
+				desired.Webhooks[i].ClientConfig.CABundle = webhook.ClientConfig.CABundle
+			}
+		}
+	}
+}
+
+// webhookDrift returns the fields in which the live MutatingWebhookConfiguration
+// differs from the desired one. Fields defaulted by the API server are ignored.
+func webhookDrift(current, desired *k8sadmissionregistrationv1.MutatingWebhookConfiguration) []string {
+	if len(current.Webhooks) != len(desired.Webhooks) {
+		return []string{"webhooks"}
+	}
+
+	drift := sets.New[string]()
+	for i := range desired.Webhooks {
+		live := withWebhookDefaults(current.Webhooks[i])
+		want := withWebhookDefaults(desired.Webhooks[i])
+
+		if live.Name != want.Name {
+			drift.Insert("name")
+		}
+		if !bytes.Equal(live.ClientConfig.CABundle, want.ClientConfig.CABundle) {
+			drift.Insert("caBundle")
+		}
+		live.ClientConfig.CABundle, want.ClientConfig.CABundle = nil, nil
+		if !equality.Semantic.DeepEqual(live.ClientConfig, want.ClientConfig) {
+			drift.Insert("clientConfig")
+		}
+		if !equality.Semantic.DeepEqual(live.NamespaceSelector, want.NamespaceSelector) {
+			drift.Insert("namespaceSelector")
+		}
+		if !equality.Semantic.DeepEqual(live.ObjectSelector, want.ObjectSelector) {
+			drift.Insert("objectSelector")
+		}
+		if !equality.Semantic.DeepEqual(live.Rules, want.Rules) {
+			drift.Insert("rules")
+		}
+		if !equality.Semantic.DeepEqual(live.FailurePolicy, want.FailurePolicy) {
+			drift.Insert("failurePolicy")
+		}
+		if !equality.Semantic.DeepEqual(live.TimeoutSeconds, want.TimeoutSeconds) {
+			drift.Insert("timeoutSeconds")
+		}
+		if !equality.Semantic.DeepEqual(live.MatchPolicy, want.MatchPolicy) ||
+			!equality.Semantic.DeepEqual(live.SideEffects, want.SideEffects) ||
+			!equality.Semantic.DeepEqual(live.ReinvocationPolicy, want.ReinvocationPolicy) ||
+			!equality.Semantic.DeepEqual(live.AdmissionReviewVersions, want.AdmissionReviewVersions) {
+			drift.Insert("policy")
+		}
+	}
+
+	return sets.List(drift)
+}
+
+// withWebhookDefaults returns a copy of the webhook with the defaults the API
+// server applies on admission, so that a freshly created object is not seen as drifted.
+func withWebhookDefaults(in k8sadmissionregistrationv1.MutatingWebhook) k8sadmissionregistrationv1.MutatingWebhook {
+	out := *in.DeepCopy()
+
+	if out.NamespaceSelector == nil {
+		out.NamespaceSelector = &metav1.LabelSelector{}
+	}
+	if out.ObjectSelector == nil {
+		out.ObjectSelector = &metav1.LabelSelector{}
+	}
+	for i := range out.Rules {
+		if out.Rules[i].Scope == nil {
+			scope := k8sadmissionregistrationv1.AllScopes
+			out.Rules[i].Scope = &scope
+		}
+	}
+	if out.ClientConfig.Service != nil && out.ClientConfig.Service.Port == nil {
+		port := int32(443)
+		out.ClientConfig.Service.Port = &port
+	}
+
+	return out
+}
+
+// setDriftCondition records whether the MutatingWebhookConfiguration had to be
+// repaired in this reconcile.
+func setDriftCondition(current *appsv1.RunOnceDurationOverride, drift []string) {
+	if len(drift) > 0 {
+		v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+			Type:    appsv1.WebhookConfigurationDrift,
+			Status:  operatorv1.ConditionTrue,
+			Reason:  appsv1.DriftRepaired,
+			Message: fmt.Sprintf("MutatingWebhookConfiguration re-applied, drift detected in %s", strings.Join(drift, ", ")),
+		})
+		return
+	}
+
+	v1helpers.SetOperatorCondition(&current.Status.Conditions, operatorv1.OperatorCondition{
+		Type:   appsv1.WebhookConfigurationDrift,
+		Status: operatorv1.ConditionFalse,
+		Reason: appsv1.AsExpected,
+	})
+}
@@ -0,0 +1,288 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+	"github.com/openshift/run-once-duration-override-operator/pkg/cert"
+)
+
+func newTestWebhookConfigurationHandler(t *testing.T, objects ...runtime.Object) (*webhookConfigurationHandler, *kubefake.Clientset) {
+	fakeKubeClient := kubefake.NewClientset(objects...)
+	kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+	webhookLister := kubeInformerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Lister()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	kubeInformerFactory.Start(ctx.Done())
+	kubeInformerFactory.WaitForCacheSync(ctx.Done())
+
+	handler := NewWebhookConfigurationHandlerHandler(
+		fakeKubeClient,
+		events.NewLoggingEventRecorder("test", clock.RealClock{}),
+		webhookLister,
+		asset.New(createTestOperandContext()),
+	)
+	return handler, fakeKubeClient
+}
+
+func TestWebhookConfigurationHandlerMode(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	name := operandAsset.NewMutatingWebhookConfiguration().Name()
+
+	tests := []struct {
+		name          string
+		mode          runoncedurationoverridev1.OverrideMode
+		existing      bool
+		expectWebhook bool
+	}{
+		{name: "Default", mode: "", existing: false, expectWebhook: true},
+		{name: "Audit", mode: runoncedurationoverridev1.OverrideModeAudit, existing: false, expectWebhook: true},
+		{name: "Disabled", mode: runoncedurationoverridev1.OverrideModeDisabled, existing: true, expectWebhook: false},
+		{name: "DisabledNotFound", mode: runoncedurationoverridev1.OverrideModeDisabled, existing: false, expectWebhook: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objects []runtime.Object
+			if tt.existing {
+				objects = append(objects, operandAsset.NewMutatingWebhookConfiguration().New())
+			}
+			handler, fakeKubeClient := newTestWebhookConfigurationHandler(t, objects...)
+
+			rodoo := createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+				rodoo.Spec.RunOnceDurationOverrideConfig.Spec.Mode = tt.mode
+			})
+
+			reconcileContext := NewReconcileRequestContext(createTestOperandContext())
+			reconcileContext.SetBundle(&cert.Bundle{ServingCertCA: []byte("test-ca")})
+
+			current, _, err := handler.Handle(reconcileContext, rodoo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, getErr := fakeKubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), name, metav1.GetOptions{})
+			switch {
+			case tt.expectWebhook && getErr != nil:
+				t.Errorf("expected MutatingWebhookConfiguration to exist, got err=%v", getErr)
+			case !tt.expectWebhook && !k8serrors.IsNotFound(getErr):
+				t.Errorf("expected MutatingWebhookConfiguration to be removed, got err=%v", getErr)
+			}
+
+			if hasRef := current.Status.Resources.MutatingWebhookConfigurationRef != nil; hasRef != tt.expectWebhook {
+				t.Errorf("expected MutatingWebhookConfigurationRef set=%t, got %t", tt.expectWebhook, hasRef)
+			}
+		})
+	}
+}
+
+func TestWebhookConfigurationHandlerSettings(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	existing := operandAsset.NewMutatingWebhookConfiguration().New()
+	existing.UID = "existing-uid"
+	handler, fakeKubeClient := newTestWebhookConfigurationHandler(t, existing)
+
+	rodoo := createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+		rodoo.Spec.Webhook.FailurePolicy = admissionregistrationv1.Ignore
+		rodoo.Spec.Webhook.TimeoutSeconds = 10
+	})
+
+	reconcileContext := NewReconcileRequestContext(createTestOperandContext())
+	reconcileContext.SetBundle(&cert.Bundle{ServingCertCA: []byte("test-ca")})
+
+	if _, _, err := handler.Handle(reconcileContext, rodoo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := fakeKubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), existing.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.UID != existing.UID {
+		t.Errorf("expected MutatingWebhookConfiguration to be updated in place, got a new object")
+	}
+
+	for _, webhook := range updated.Webhooks {
+		if webhook.FailurePolicy == nil || *webhook.FailurePolicy != admissionregistrationv1.Ignore {
+			t.Errorf("webhook=%s expected failurePolicy Ignore, got %v", webhook.Name, webhook.FailurePolicy)
+		}
+		if webhook.TimeoutSeconds == nil || *webhook.TimeoutSeconds != 10 {
+			t.Errorf("webhook=%s expected timeoutSeconds 10, got %v", webhook.Name, webhook.TimeoutSeconds)
+		}
+	}
+
+	for _, action := range fakeKubeClient.Actions() {
+		if action.GetVerb() == "delete" || action.GetVerb() == "create" {
+			t.Errorf("unexpected %s action on %s", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+}
+
+func TestWebhookConfigurationHandlerDrift(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	existing := operandAsset.NewMutatingWebhookConfiguration().New()
+	existing.Webhooks[0].ClientConfig.CABundle = []byte("stale-ca")
+	existing.Webhooks[0].NamespaceSelector = &metav1.LabelSelector{}
+
+	fakeKubeClient := kubefake.NewClientset(existing)
+	kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+	webhookLister := kubeInformerFactory.Admissionregistration().V1().MutatingWebhookConfigurations().Lister()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeInformerFactory.Start(ctx.Done())
+	kubeInformerFactory.WaitForCacheSync(ctx.Done())
+
+	recorder := events.NewInMemoryRecorder("test", clock.RealClock{})
+	handler := NewWebhookConfigurationHandlerHandler(fakeKubeClient, recorder, webhookLister, operandAsset)
+
+	reconcileContext := NewReconcileRequestContext(createTestOperandContext())
+	reconcileContext.SetBundle(&cert.Bundle{ServingCertCA: []byte("test-ca")})
+
+	current, _, err := handler.Handle(reconcileContext, createTestRodoo(3600, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := fakeKubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, existing.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if drift := webhookDrift(updated, handler.NewDesired(reconcileContext, current)); len(drift) != 0 {
+		t.Errorf("expected drift to be repaired, still differs in %v", drift)
+	}
+
+	verifyCondition(t, current, runoncedurationoverridev1.WebhookConfigurationDrift, operatorv1.ConditionTrue, runoncedurationoverridev1.DriftRepaired)
+	condition := findCondition(current.Status.Conditions, runoncedurationoverridev1.WebhookConfigurationDrift)
+	if !strings.Contains(condition.Message, "caBundle") || !strings.Contains(condition.Message, "namespaceSelector") {
+		t.Errorf("expected drifted fields in condition message, got %q", condition.Message)
+	}
+
+	found := false
+	for _, event := range recorder.Events() {
+		if event.Reason == "MutatingWebhookConfigurationDrift" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a MutatingWebhookConfigurationDrift event")
+	}
+}
+
+func TestWebhookDriftIgnoresServerDefaults(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	desired := operandAsset.NewMutatingWebhookConfiguration().New()
+
+	live := desired.DeepCopy()
+	scope := admissionregistrationv1.AllScopes
+	live.Webhooks[0].ObjectSelector = &metav1.LabelSelector{}
+	live.Webhooks[0].Rules[0].Scope = &scope
+
+	if drift := webhookDrift(live, desired); len(drift) != 0 {
+		t.Errorf("expected no drift for server defaulted fields, got %v", drift)
+	}
+
+	live.Webhooks[0].Rules[0].Operations = append(live.Webhooks[0].Rules[0].Operations, admissionregistrationv1.Delete)
+	if drift := webhookDrift(live, desired); len(drift) != 1 || drift[0] != "rules" {
+		t.Errorf("expected drift in rules, got %v", drift)
+	}
+}
+
+func TestWebhookConfigurationHandlerSelectors(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	handler, fakeKubeClient := newTestWebhookConfigurationHandler(t, operandAsset.NewMutatingWebhookConfiguration().New())
+
+	rodoo := createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+		rodoo.Spec.Webhook.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"tenant-group": "batch"},
+		}
+		rodoo.Spec.Webhook.ObjectSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "runoncedurationoverride/opt-out", Operator: metav1.LabelSelectorOpDoesNotExist},
+			},
+		}
+	})
+
+	reconcileContext := NewReconcileRequestContext(createTestOperandContext())
+	reconcileContext.SetBundle(&cert.Bundle{ServingCertCA: []byte("test-ca")})
+
+	current, _, err := handler.Handle(reconcileContext, rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyCondition(t, current, runoncedurationoverridev1.WebhookConfigurationDrift, operatorv1.ConditionTrue, runoncedurationoverridev1.DriftRepaired)
+
+	updated, err := fakeKubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), operandAsset.NewMutatingWebhookConfiguration().Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	webhook := updated.Webhooks[0]
+	if webhook.NamespaceSelector.MatchLabels["tenant-group"] != "batch" {
+		t.Errorf("expected configured namespaceSelector, got %v", webhook.NamespaceSelector)
+	}
+	if _, ok := webhook.NamespaceSelector.MatchLabels["runoncedurationoverrides.admission.runoncedurationoverride.openshift.io/enabled"]; ok {
+		t.Errorf("expected default opt-in label to be replaced, got %v", webhook.NamespaceSelector)
+	}
+	if len(webhook.NamespaceSelector.MatchExpressions) != 1 || webhook.NamespaceSelector.MatchExpressions[0].Key != "openshift.io/run-level" {
+		t.Errorf("expected run-level exclusion to be kept, got %v", webhook.NamespaceSelector.MatchExpressions)
+	}
+	if webhook.ObjectSelector == nil || len(webhook.ObjectSelector.MatchExpressions) != 1 {
+		t.Errorf("expected configured objectSelector, got %v", webhook.ObjectSelector)
+	}
+	if drift := webhookDrift(updated, handler.NewDesired(reconcileContext, rodoo)); len(drift) != 0 {
+		t.Errorf("expected no drift against configured selectors, got %v", drift)
+	}
+}
+
+func TestWebhookConfigurationHandlerWithoutServingCert(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	existing := operandAsset.NewMutatingWebhookConfiguration().New()
+	for i := range existing.Webhooks {
+		existing.Webhooks[i].ClientConfig.CABundle = []byte("applied-ca")
+		existing.Webhooks[i].TimeoutSeconds = nil
+	}
+	handler, fakeKubeClient := newTestWebhookConfigurationHandler(t, existing)
+
+	rodoo := createTestRodoo(3600, func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+		rodoo.Spec.Webhook.TimeoutSeconds = 10
+	})
+
+	// No bundle in the context, the serving cert is not usable.
+	if _, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := fakeKubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.TODO(), existing.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, webhook := range updated.Webhooks {
+		if webhook.TimeoutSeconds == nil || *webhook.TimeoutSeconds != 10 {
+			t.Errorf("webhook=%s expected the drift to be repaired, got timeoutSeconds=%v", webhook.Name, webhook.TimeoutSeconds)
+		}
+		if string(webhook.ClientConfig.CABundle) != "applied-ca" {
+			t.Errorf("webhook=%s expected the CA bundle last applied, got %q", webhook.Name, webhook.ClientConfig.CABundle)
+		}
+	}
+
+	// Without a registered webhook there is no CA bundle to register it with.
+	handler, _ = newTestWebhookConfigurationHandler(t)
+	_, _, err = handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if GetReason(err) != runoncedurationoverridev1.AdmissionWebhookNotAvailable {
+		t.Errorf("expected reason %s, got %v", runoncedurationoverridev1.AdmissionWebhookNotAvailable, err)
+	}
+}
@@ -0,0 +1,60 @@
+package targetconfigcontroller
+
+import (
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/klog/v2"
+	controllerreconciler "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+)
+
+func NewWorkloadSwitchHandler(remover *removalHandler) *workloadSwitchHandler {
+	return &workloadSwitchHandler{
+		remover: remover,
+	}
+}
+
+// workloadSwitchHandler removes the workload the webhook server ran as before a
+// switch of spec.workload. It runs once the new workload is ready and the webhook
+// configuration points at it, so that pod admission is never left without a server.
+type workloadSwitchHandler struct {
+	remover *removalHandler
+}
+
+func (w *workloadSwitchHandler) Handle(context *ReconcileRequestContext, original *appsv1.RunOnceDurationOverride) (current *appsv1.RunOnceDurationOverride, result controllerreconciler.Result, handleErr error) {
+	current = original
+
+	desired := original.Spec.GetWorkload()
+	previous := original.Status.Workload
+	if previous == "" {
+		// Installs that predate spec.workload run the DaemonSet.
+		previous = appsv1.WorkloadDaemonSet
+	}
+
+	if previous != desired {
+		if err := w.remover.RemoveWorkload(previous); err != nil {
+			handleErr = NewInstallReadinessError(appsv1.CannotRemoveOperand, err)
+			return
+		}
+
+		resource := "daemonsets"
+		if previous == appsv1.WorkloadDeployment {
+			resource = "deployments"
+			current.Status.Resources.ServiceRef = nil
+		}
+
+		generations := []operatorv1.GenerationStatus{}
+		for _, generation := range current.Status.Generations {
+			if generation.Group == "apps" && generation.Resource == resource {
+				continue
+			}
+			generations = append(generations, generation)
+		}
+		current.Status.Generations = generations
+
+		klog.V(2).Infof("key=%s switched workload from %s to %s", original.Name, previous, desired)
+	}
+
+	current.Status.Workload = desired
+	return
+}
@@ -0,0 +1,188 @@
+package targetconfigcontroller
+
+import (
+	"context"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	runoncedurationoverridev1 "github.com/openshift/run-once-duration-override-operator/pkg/apis/runoncedurationoverride/v1"
+	"github.com/openshift/run-once-duration-override-operator/pkg/asset"
+	"github.com/openshift/run-once-duration-override-operator/pkg/cert"
+	"github.com/openshift/run-once-duration-override-operator/pkg/deploy"
+)
+
+func withWorkload(workload runoncedurationoverridev1.WorkloadType) func(*runoncedurationoverridev1.RunOnceDurationOverride) {
+	return func(rodoo *runoncedurationoverridev1.RunOnceDurationOverride) {
+		rodoo.Spec.Workload = workload
+	}
+}
+
+func TestWorkloadSwitchHandler(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+
+	tests := []struct {
+		name     string
+		previous runoncedurationoverridev1.WorkloadType
+		desired  runoncedurationoverridev1.WorkloadType
+	}{
+		{name: "DaemonSetToDeployment", previous: runoncedurationoverridev1.WorkloadDaemonSet, desired: runoncedurationoverridev1.WorkloadDeployment},
+		{name: "UnsetToDeployment", previous: "", desired: runoncedurationoverridev1.WorkloadDeployment},
+		{name: "DeploymentToDaemonSet", previous: runoncedurationoverridev1.WorkloadDeployment, desired: runoncedurationoverridev1.WorkloadDaemonSet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeKubeClient := kubefake.NewClientset(
+				operandAsset.DaemonSet().New(),
+				operandAsset.Deployment().New(),
+				operandAsset.Service().New(),
+			)
+			handler := NewWorkloadSwitchHandler(NewRemovalHandler(fakeKubeClient, events.NewLoggingEventRecorder("test", clock.RealClock{}), operandAsset))
+
+			rodoo := createTestRodoo(3600, withWorkload(tt.desired))
+			rodoo.Status.Workload = tt.previous
+			rodoo.Status.Resources.ServiceRef = &corev1.ObjectReference{Name: operandAsset.Service().Name()}
+			rodoo.Status.Generations = []operatorv1.GenerationStatus{
+				{Group: "apps", Resource: "daemonsets", Namespace: "test-namespace", Name: "test-operator", LastGeneration: 1},
+				{Group: "apps", Resource: "deployments", Namespace: "test-namespace", Name: "test-operator", LastGeneration: 1},
+			}
+
+			current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ctx := context.TODO()
+			_, dsErr := fakeKubeClient.AppsV1().DaemonSets("test-namespace").Get(ctx, operandAsset.DaemonSet().Name(), metav1.GetOptions{})
+			_, deploymentErr := fakeKubeClient.AppsV1().Deployments("test-namespace").Get(ctx, operandAsset.Deployment().Name(), metav1.GetOptions{})
+			_, serviceErr := fakeKubeClient.CoreV1().Services("test-namespace").Get(ctx, operandAsset.Service().Name(), metav1.GetOptions{})
+
+			if tt.desired == runoncedurationoverridev1.WorkloadDeployment {
+				if !k8serrors.IsNotFound(dsErr) {
+					t.Errorf("expected DaemonSet to be removed, got err=%v", dsErr)
+				}
+				if deploymentErr != nil || serviceErr != nil {
+					t.Errorf("expected Deployment and Service to be kept, got err=%v, %v", deploymentErr, serviceErr)
+				}
+			} else {
+				if dsErr != nil {
+					t.Errorf("expected DaemonSet to be kept, got err=%v", dsErr)
+				}
+				if !k8serrors.IsNotFound(deploymentErr) || !k8serrors.IsNotFound(serviceErr) {
+					t.Errorf("expected Deployment and Service to be removed, got err=%v, %v", deploymentErr, serviceErr)
+				}
+				if current.Status.Resources.ServiceRef != nil {
+					t.Errorf("expected ServiceRef to be cleared")
+				}
+			}
+
+			if current.Status.Workload != tt.desired {
+				t.Errorf("expected status workload %q, got %q", tt.desired, current.Status.Workload)
+			}
+			if len(current.Status.Generations) != 1 {
+				t.Errorf("expected the generation of the removed workload to be dropped, got %+v", current.Status.Generations)
+			}
+		})
+	}
+}
+
+func TestWebhookConfigurationHandlerServiceReference(t *testing.T) {
+	handler, _ := newTestWebhookConfigurationHandler(t)
+
+	reconcileContext := NewReconcileRequestContext(createTestOperandContext())
+	reconcileContext.SetBundle(&cert.Bundle{ServingCertCA: []byte("test-ca")})
+
+	desired := handler.NewDesired(reconcileContext, createTestRodoo(3600, withWorkload(runoncedurationoverridev1.WorkloadDeployment)))
+	for _, webhook := range desired.Webhooks {
+		service := webhook.ClientConfig.Service
+		if webhook.ClientConfig.URL != nil || service == nil {
+			t.Fatalf("webhook=%s expected a service reference, got %+v", webhook.Name, webhook.ClientConfig)
+		}
+		if service.Name != "test-operator" || service.Namespace != "test-namespace" || service.Port == nil || *service.Port != 443 {
+			t.Errorf("webhook=%s unexpected service reference %+v", webhook.Name, service)
+		}
+	}
+
+	desired = handler.NewDesired(reconcileContext, createTestRodoo(3600, nil))
+	for _, webhook := range desired.Webhooks {
+		if webhook.ClientConfig.Service != nil || webhook.ClientConfig.URL == nil {
+			t.Errorf("webhook=%s expected a localhost URL, got %+v", webhook.Name, webhook.ClientConfig)
+		}
+	}
+}
+
+func TestDaemonSetHandlerDeploymentWorkload(t *testing.T) {
+	operandAsset := asset.New(createTestOperandContext())
+	fakeKubeClient := kubefake.NewClientset()
+	recorder := events.NewLoggingEventRecorder("test", clock.RealClock{})
+
+	kubeInformerFactory := informers.NewSharedInformerFactory(fakeKubeClient, 0)
+	deployInterface := deploy.NewDeploymentInstall(kubeInformerFactory.Apps().V1().Deployments().Lister(), createTestOperandContext(), operandAsset, fakeKubeClient, recorder)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeInformerFactory.Start(ctx.Done())
+	kubeInformerFactory.WaitForCacheSync(ctx.Done())
+
+	handler := NewDaemonSetHandler(fakeKubeClient, recorder, operandAsset, deployInterface)
+
+	rodoo := createTestRodoo(3600, withWorkload(runoncedurationoverridev1.WorkloadDeployment))
+	rodoo.Spec.Replicas = 3
+
+	current, _, err := handler.Handle(NewReconcileRequestContext(createTestOperandContext()), rodoo)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deployment, err := fakeKubeClient.AppsV1().Deployments("test-namespace").Get(ctx, operandAsset.Deployment().Name(), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected Deployment to be created: %v", err)
+	}
+	if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 3 {
+		t.Errorf("expected 3 replicas, got %v", deployment.Spec.Replicas)
+	}
+	if deployment.Spec.Template.Spec.HostNetwork {
+		t.Errorf("expected the Deployment not to use hostNetwork")
+	}
+	if current.Status.Resources.DeploymentRef == nil || current.Status.Resources.DeploymentRef.Kind != "Deployment" {
+		t.Errorf("expected DeploymentRef to point at the Deployment, got %+v", current.Status.Resources.DeploymentRef)
+	}
+	if len(current.Status.Generations) != 1 || current.Status.Generations[0].Resource != "deployments" {
+		t.Errorf("expected the Deployment generation to be recorded, got %+v", current.Status.Generations)
+	}
+}
+
+func TestGetDeploymentStatus(t *testing.T) {
+	replicas := int32(2)
+	tests := []struct {
+		name   string
+		status k8sappsv1.DeploymentStatus
+		ready  bool
+	}{
+		{name: "Available", status: k8sappsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}, ready: true},
+		{name: "RollingOut", status: k8sappsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2}, ready: false},
+		{name: "NotAvailable", status: k8sappsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1}, ready: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &k8sappsv1.Deployment{
+				Spec:   k8sappsv1.DeploymentSpec{Replicas: &replicas},
+				Status: tt.status,
+			}
+
+			ready, err := deploy.GetDeploymentStatus(deployment)
+			if ready != tt.ready {
+				t.Errorf("expected ready=%t, got %t (err=%v)", tt.ready, ready, err)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	o "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sclient "k8s.io/client-go/kubernetes"
@@ -60,6 +61,13 @@ var _ = g.Describe("[sig-scheduling][Operator][Serial] RunOnceDurationOverride O
 			})
 		})
 	})
+
+	g.Context("when the RunOnceDurationOverride is deleted", func() {
+		g.It("should remove the cluster scoped resources and the finalizer [Suite:openshift/run-once-duration-override-operator/operator/serial]", func() {
+			g.By("Deleting the RunOnceDurationOverride and waiting for it to be removed")
+			testDeleteRunOnceDurationOverride(g.GinkgoTB(), ctx, kubeClient)
+		})
+	})
 })
 
 // setupOperator sets up the operator and waits for it to be ready.
@@ -407,3 +415,59 @@ func cleanupTestNamespace(t testing.TB, ctx context.Context, kubeClient *k8sclie
 		klog.Errorf("Failed to delete test namespace: %v", err)
 	}
 }
+
+// testDeleteRunOnceDurationOverride deletes the RunOnceDurationOverride and
+// checks that the operator removes its finalizer once the cluster scoped
+// resources of the operand are gone. It needs the delete verb on the RBAC
+// resources, which the fake clientset of the unit tests does not check.
+// This function works with both standard Go testing and Ginkgo.
+func testDeleteRunOnceDurationOverride(t testing.TB, ctx context.Context, kubeClient *k8sclient.Clientset) {
+	runOnceDurationOverrideClient := GetRunOnceDurationOverrideClient()
+
+	klog.Infof("Deleting the RunOnceDurationOverride")
+	err := runOnceDurationOverrideClient.RunOnceDurationOverrideV1().RunOnceDurationOverrides().Delete(ctx, "cluster", metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Failed to delete the RunOnceDurationOverride: %v", err)
+	}
+
+	klog.Infof("Waiting for the RunOnceDurationOverride to be removed")
+	deadline := time.Now().Add(2 * time.Minute)
+	removed := false
+	for time.Now().Before(deadline) {
+		_, err := runOnceDurationOverrideClient.RunOnceDurationOverrideV1().RunOnceDurationOverrides().Get(ctx, "cluster", metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			removed = true
+			break
+		}
+		time.Sleep(5 * time.Second)
+	}
+	if !removed {
+		t.Fatalf("RunOnceDurationOverride still exists after timeout, the finalizer was not removed")
+	}
+
+	mutatingWebhooks, err := kubeClient.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list mutating webhook configurations: %v", err)
+	}
+	for _, mutatingWebhook := range mutatingWebhooks.Items {
+		if strings.HasPrefix(mutatingWebhook.Name, "runoncedurationoverrides") {
+			t.Errorf("mutating webhook configuration %s should have been removed", mutatingWebhook.Name)
+		}
+	}
+
+	ownerSelector := metav1.ListOptions{LabelSelector: "operator.apps.openshift.io/runoncedurationoverride=true"}
+	clusterRoles, err := kubeClient.RbacV1().ClusterRoles().List(ctx, ownerSelector)
+	if err != nil {
+		t.Fatalf("Failed to list cluster roles: %v", err)
+	}
+	for _, clusterRole := range clusterRoles.Items {
+		t.Errorf("cluster role %s should have been removed", clusterRole.Name)
+	}
+	clusterRoleBindings, err := kubeClient.RbacV1().ClusterRoleBindings().List(ctx, ownerSelector)
+	if err != nil {
+		t.Fatalf("Failed to list cluster role bindings: %v", err)
+	}
+	for _, clusterRoleBinding := range clusterRoleBindings.Items {
+		t.Errorf("cluster role binding %s should have been removed", clusterRoleBinding.Name)
+	}
+}
@@ -30,5 +30,10 @@ func TestExtended(t *testing.T) {
 			testNamespace := testActiveDeadlineSecondsWebhook(t, ctx, kubeClient)
 			defer cleanupTestNamespace(t, ctx, kubeClient, testNamespace)
 		})
+
+		// Runs last, it removes the operand.
+		t.Run("RunOnceDurationOverride deletion", func(t *testing.T) {
+			testDeleteRunOnceDurationOverride(t, ctx, kubeClient)
+		})
 	})
 }